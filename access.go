@@ -0,0 +1,181 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// Type of the parental control schedules.
+const scheduleTypeToD = "ToD"
+
+// Blocked returns the devices whose internet access is currently blocked by
+// parental control, along with the reason and the schedule that blocks them.
+func (d *Devices) Blocked(ctx context.Context) ([]response.BlockedDevice, error) {
+	schedules, err := d.client.getSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := d.client.getDevices(ctx, "physical")
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]response.Device, len(devices))
+	for _, device := range devices {
+		byKey[device.Key] = device
+	}
+
+	var blocked []response.BlockedDevice
+
+	for _, schedule := range schedules {
+		if !schedule.Enable || schedule.Value != response.ScheduleStateDisable {
+			continue
+		}
+
+		device, ok := byKey[schedule.ID]
+		if !ok {
+			device = response.Device{Key: schedule.ID, PhysAddress: schedule.ID}
+		}
+
+		reason := response.BlockReasonSchedule
+		if schedule.Override == response.ScheduleStateDisable {
+			reason = response.BlockReasonPaused
+		}
+
+		blocked = append(blocked, response.BlockedDevice{
+			Device:   device,
+			Reason:   reason,
+			Schedule: schedule,
+		})
+	}
+
+	return blocked, nil
+}
+
+// Block pauses the internet access of the device with the given MAC address,
+// regardless of its parental control schedule, until Unblock is called.
+func (d *Devices) Block(ctx context.Context, mac string) error {
+	return d.override(ctx, mac, response.ScheduleStateDisable)
+}
+
+// Unblock resumes the internet access of a device paused with Block. The
+// device is still blocked during the periods of its parental control
+// schedule, if any.
+func (d *Devices) Unblock(ctx context.Context, mac string) error {
+	return d.override(ctx, mac, "")
+}
+
+// Schedule returns the parental control schedule of the device with the given
+// MAC address, or nil if it has none.
+func (d *Devices) Schedule(ctx context.Context, mac string) (*response.Schedule, error) {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := d.client.getSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.ID == key {
+			return &schedule, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// SetSchedule replaces the periods of the week during which the internet
+// access of the device with the given MAC address is blocked. The schedule is
+// created if the device has none, a pause set with Block is kept. The state
+// of the periods is set to ScheduleStateDisable.
+func (d *Devices) SetSchedule(ctx context.Context, mac string, periods []response.SchedulePeriod) error {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return err
+	}
+
+	current, err := d.Schedule(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	override := ""
+	if current != nil {
+		override = current.Override
+	}
+
+	blocked := make([]response.SchedulePeriod, len(periods))
+	for i, period := range periods {
+		period.State = response.ScheduleStateDisable
+		blocked[i] = period
+	}
+
+	return d.client.addSchedule(ctx, key, override, blocked)
+}
+
+// override forces the state of the schedule of a device, the schedule is
+// created if the device has none. An empty state removes the override.
+func (d *Devices) override(ctx context.Context, mac, state string) error {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return err
+	}
+
+	schedules, err := d.client.getSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.ID == key {
+			return d.client.requestBool(ctx, sah.Scheduler.OverrideSchedule.Request(request.Parameters{
+				"type":     scheduleTypeToD,
+				"ID":       key,
+				"override": state,
+			}))
+		}
+	}
+
+	// Without a schedule, the device is not blocked.
+	if state == "" {
+		return nil
+	}
+
+	return d.client.addSchedule(ctx, key, state, []response.SchedulePeriod{})
+}
+
+// addSchedule creates the weekly schedule of a device, or replaces it.
+func (c *Client) addSchedule(ctx context.Context, key, override string, periods []response.SchedulePeriod) error {
+	return c.requestBool(ctx, sah.Scheduler.AddSchedule.Request(request.Parameters{
+		"type": scheduleTypeToD,
+		"info": request.Parameters{
+			"ID":       key,
+			"enable":   true,
+			"base":     "Weekly",
+			"def":      response.ScheduleStateEnable,
+			"override": override,
+			"schedule": periods,
+		},
+	}))
+}
+
+// getSchedules returns the parental control schedules.
+func (c *Client) getSchedules(ctx context.Context) ([]response.Schedule, error) {
+	var out response.StatusData[bool, struct {
+		ScheduleInfo []response.Schedule `json:"scheduleInfo"`
+	}]
+	if err := c.Request(ctx, sah.Scheduler.GetCompleteSchedules.Request(request.Parameters{
+		"type": scheduleTypeToD,
+	}), &out); err != nil {
+		return nil, err
+	}
+
+	return out.Data.ScheduleInfo, nil
+}
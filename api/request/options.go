@@ -0,0 +1,82 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Options are the options of a single request.
+type Options struct {
+	// Timeout of the request, including retries. No timeout is applied if 0.
+	Timeout time.Duration
+	// Do not try to renew the session if it is expired.
+	NoAutoReauth bool
+	// Additional HTTP headers sent with the request.
+	Header http.Header
+	// Content-Type of the request, the default one is used if empty.
+	ContentType string
+	// Retry policy of the request, the one of the client is used if nil.
+	RetryPolicy RetryPolicy
+}
+
+// RetryPolicy retries requests that failed, such as a *livebox.RetryPolicy.
+type RetryPolicy interface {
+	// Retry calls send until it succeeds or the request must not be retried
+	// anymore, and returns the last error.
+	Retry(ctx context.Context, send func() error) error
+}
+
+// Option is a request option.
+type Option func(o *Options)
+
+// NewOptions returns the Options set by opts.
+func NewOptions(opts []Option) *Options {
+	o := &Options{Header: http.Header{}}
+
+	for _, f := range opts {
+		f(o)
+	}
+
+	return o
+}
+
+// WithTimeout sets a timeout on the request. The timeout includes the time
+// spent retrying the request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = timeout
+	}
+}
+
+// WithoutAutoReauth disables the automatic renewal of the session if it is
+// expired. The permission denied error is returned instead.
+func WithoutAutoReauth() Option {
+	return func(o *Options) {
+		o.NoAutoReauth = true
+	}
+}
+
+// WithHeader adds an HTTP header to the request.
+func WithHeader(key, value string) Option {
+	return func(o *Options) {
+		o.Header.Add(key, value)
+	}
+}
+
+// WithContentType overrides the Content-Type of the request.
+func WithContentType(contentType string) Option {
+	return func(o *Options) {
+		o.ContentType = contentType
+	}
+}
+
+// WithRetryPolicy overrides the retry policy of the client for the request.
+// Unlike the policy of the client, it also applies to requests that modify
+// the configuration of the Livebox: they are retried even though they may
+// already have been applied.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *Options) {
+		o.RetryPolicy = policy
+	}
+}
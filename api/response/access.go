@@ -0,0 +1,55 @@
+package response
+
+// Values of the state of a schedule.
+const (
+	ScheduleStateEnable  = "Enable"
+	ScheduleStateDisable = "Disable"
+)
+
+// Schedule is a parental control schedule of a device. A device is blocked
+// when the current value of its schedule is ScheduleStateDisable.
+type Schedule struct {
+	// ID of the schedule, the key of the device it applies to.
+	ID string `json:"ID"`
+	// Whether the schedule is enabled.
+	Enable bool `json:"enable"`
+	// Base of the schedule, usually "Weekly".
+	Base string `json:"base"`
+	// State of the device outside of the scheduled periods.
+	Default string `json:"def"`
+	// State forced by the user regardless of the schedule, empty if none.
+	Override string `json:"override"`
+	// Current state of the device.
+	Value string `json:"value"`
+	// Scheduled periods.
+	Periods []SchedulePeriod `json:"schedule"`
+}
+
+// SchedulePeriod is a period of a schedule. Begin and End are expressed in
+// seconds since Monday 00:00.
+type SchedulePeriod struct {
+	Begin int    `json:"begin"`
+	End   int    `json:"end"`
+	State string `json:"state"`
+}
+
+// BlockReason tells why a device is blocked.
+type BlockReason string
+
+const (
+	// BlockReasonPaused is used when the access of the device was manually
+	// paused.
+	BlockReasonPaused BlockReason = "paused"
+	// BlockReasonSchedule is used when the device is blocked by its schedule.
+	BlockReasonSchedule BlockReason = "schedule"
+)
+
+// BlockedDevice is a device whose internet access is currently blocked.
+type BlockedDevice struct {
+	// The blocked device.
+	Device Device
+	// Why the device is blocked.
+	Reason BlockReason
+	// Schedule that blocks the device.
+	Schedule Schedule
+}
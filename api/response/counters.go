@@ -0,0 +1,33 @@
+package response
+
+// InterfaceCounters contains the traffic counters of a network interface.
+type InterfaceCounters struct {
+	// Name of the interface. It is not part of the API response and is set
+	// by the client.
+	Interface string `json:"-"`
+
+	RxBytes   uint64 `json:"RxBytes"`
+	TxBytes   uint64 `json:"TxBytes"`
+	RxPackets uint64 `json:"RxPackets"`
+	TxPackets uint64 `json:"TxPackets"`
+	RxErrors  uint64 `json:"RxErrors"`
+	TxErrors  uint64 `json:"TxErrors"`
+	RxDropped uint64 `json:"RxDropped"`
+	TxDropped uint64 `json:"TxDropped"`
+	Multicast uint64 `json:"Multicast"`
+}
+
+// DeviceCounters contains the traffic counters of a device of the home
+// network, as measured by the Livebox.
+type DeviceCounters struct {
+	// MAC address of the device. It is not part of the API response and is
+	// set by the client.
+	MACAddress string `json:"-"`
+
+	// Time of the reading, in seconds since the Unix epoch.
+	Timestamp int64 `json:"Timestamp"`
+	// Bytes received from the device.
+	RxBytes uint64 `json:"Rx_Counter"`
+	// Bytes sent to the device.
+	TxBytes uint64 `json:"Tx_Counter"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+// DeviceInfo contains general information about the Livebox.
+type DeviceInfo struct {
+	Manufacturer    string `json:"Manufacturer"`
+	ModelName       string `json:"ModelName"`
+	ProductClass    string `json:"ProductClass"`
+	SerialNumber    string `json:"SerialNumber"`
+	HardwareVersion string `json:"HardwareVersion"`
+	SoftwareVersion string `json:"SoftwareVersion"`
+	// MAC address of the Livebox.
+	BaseMAC string `json:"BaseMAC"`
+	// Time since the last boot, in seconds.
+	UpTime int64 `json:"UpTime"`
+	// Number of reboots since the last factory reset.
+	NumberOfReboots int `json:"NumberOfReboots"`
+}
+
+// Uptime returns the time since the last boot.
+func (i *DeviceInfo) Uptime() time.Duration {
+	return time.Duration(i.UpTime) * time.Second
+}
+
+// Reboot is an entry of the reboot history.
+type Reboot struct {
+	// Time at which the Livebox booted.
+	BootDate time.Time `json:"BootDate"`
+	// Reason of the reboot (e.g. "GUI_Reboot", "Upgrade", "PowerOn").
+	Reason string `json:"Reason"`
+}
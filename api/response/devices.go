@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+// Device is a device known by the Livebox.
+type Device struct {
+	// Unique key of the device, usually its MAC address.
+	Key string `json:"Key"`
+	// Friendly name of the device.
+	Name string `json:"Name"`
+	// Type of the device (e.g. "Computer", "Phone").
+	DeviceType string `json:"DeviceType"`
+	// Whether the device is currently connected.
+	Active bool `json:"Active"`
+	// Space-separated list of tags attached to the device.
+	Tags string `json:"Tags"`
+	// MAC address of the device.
+	PhysAddress string `json:"PhysAddress"`
+	// Current IPv4 address of the device.
+	IPAddress string `json:"IPAddress"`
+	// Layer 2 interface the device is connected to (e.g. "ETH1", "wl0").
+	Layer2Interface string `json:"Layer2Interface"`
+	// Last time the device connected to the network.
+	LastConnection time.Time `json:"LastConnection"`
+	// Last time the device changed.
+	LastChanged time.Time `json:"LastChanged"`
+}
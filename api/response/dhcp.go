@@ -0,0 +1,22 @@
+package response
+
+// DHCPLease is a lease of the DHCP server.
+type DHCPLease struct {
+	// Name given by the client.
+	FriendlyName string `json:"FriendlyName"`
+	MACAddress   string `json:"MACAddress"`
+	IPAddress    string `json:"IPAddress"`
+	// Whether the lease is currently in use.
+	Active bool `json:"Active"`
+	// Whether the lease is a static lease.
+	Reserved bool `json:"Reserved"`
+	// Remaining time of the lease, in seconds.
+	LeaseTimeRemaining int `json:"LeaseTimeRemaining"`
+}
+
+// StaticLease is a static lease of the DHCP server, which always gives the
+// same address to a device.
+type StaticLease struct {
+	MACAddress string `json:"MACAddress"`
+	IPAddress  string `json:"IPAddress"`
+}
@@ -0,0 +1,69 @@
+package response
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiagnosticsStateComplete is the state of a diagnostic that completed.
+const DiagnosticsStateComplete = "Complete"
+
+// PingResult is the result of a ping sent by the Livebox.
+type PingResult struct {
+	// State of the diagnostic, DiagnosticsStateComplete on success.
+	DiagnosticsState string `json:"DiagnosticsState"`
+	// Number of replies received.
+	SuccessCount int `json:"SuccessCount"`
+	// Number of requests that were not answered.
+	FailureCount int `json:"FailureCount"`
+	// Average round-trip time, in milliseconds.
+	AverageResponseTime int `json:"AverageResponseTime"`
+	// Minimum round-trip time, in milliseconds.
+	MinimumResponseTime int `json:"MinimumResponseTime"`
+	// Maximum round-trip time, in milliseconds.
+	MaximumResponseTime int `json:"MaximumResponseTime"`
+}
+
+// Average returns the average round-trip time.
+func (r *PingResult) Average() time.Duration {
+	return time.Duration(r.AverageResponseTime) * time.Millisecond
+}
+
+// TracerouteResult is the result of a traceroute run by the Livebox.
+type TracerouteResult struct {
+	// State of the diagnostic, DiagnosticsStateComplete on success.
+	DiagnosticsState string `json:"DiagnosticsState"`
+	// Round-trip time to the destination, in milliseconds.
+	ResponseTime int `json:"ResponseTime"`
+	// Hops to the destination.
+	RouteHops []TracerouteHop `json:"RouteHops"`
+}
+
+// TracerouteHop is a hop of a traceroute.
+type TracerouteHop struct {
+	// Name of the hop, if it could be resolved.
+	Host string `json:"Host"`
+	// IP address of the hop.
+	HostAddress string `json:"HostAddress"`
+	// ICMP error code returned by the hop.
+	ErrorCode int `json:"ErrorCode"`
+	// Comma-separated round-trip times to the hop, in milliseconds.
+	RTTimes string `json:"RTTimes"`
+}
+
+// RTTs returns the round-trip times to the hop. Invalid values are ignored.
+func (h *TracerouteHop) RTTs() []time.Duration {
+	var rtts []time.Duration
+
+	for _, v := range strings.Split(h.RTTimes, ",") {
+		ms, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+
+		rtts = append(rtts, time.Duration(ms)*time.Millisecond)
+	}
+
+	return rtts
+}
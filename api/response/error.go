@@ -3,15 +3,48 @@ package response
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 // ErrorCode is the code of the error.
 type ErrorCode int
 
-// PermissionDeniedErrorCode is returned by the server when the requested action
-// is denied. This is mostly due to an invalid or expired session.
-const PermissionDeniedErrorCode ErrorCode = 13
+// Error codes known to be returned by the Livebox API.
+const (
+	// PermissionDeniedErrorCode is returned by the server when the requested
+	// action is denied. This is mostly due to an invalid or expired session.
+	PermissionDeniedErrorCode ErrorCode = 13
+	// InvalidParameterErrorCode is returned when a parameter of the called
+	// function has an invalid value.
+	InvalidParameterErrorCode ErrorCode = 22
+	// ObjectNotFoundErrorCode is returned when the called object, or a
+	// parameter of this object, does not exist.
+	ObjectNotFoundErrorCode ErrorCode = 196618
+	// MissingArgumentErrorCode is returned when a mandatory argument of the
+	// called function is missing.
+	MissingArgumentErrorCode ErrorCode = 196621
+	// FunctionExecutionFailedErrorCode is returned when the called function
+	// failed, often because the Livebox is busy.
+	FunctionExecutionFailedErrorCode ErrorCode = 196639
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	PermissionDeniedErrorCode:        "permission denied",
+	InvalidParameterErrorCode:        "invalid parameter",
+	ObjectNotFoundErrorCode:          "object not found",
+	MissingArgumentErrorCode:         "missing argument",
+	FunctionExecutionFailedErrorCode: "function execution failed",
+}
+
+// String returns the name of a known error code, or the code itself.
+func (c ErrorCode) String() string {
+	if name, ok := errorCodeNames[c]; ok {
+		return name
+	}
+
+	return strconv.Itoa(int(c))
+}
 
 // Errors is a response that may be returned by the API when it cannot
 // successfully respond to the user request.
@@ -34,6 +67,17 @@ func (e *Errors) Error() string {
 	return str.String()
 }
 
+// Unwrap returns all the errors, so that errors.Is and errors.As match any of
+// them.
+func (e *Errors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
 // Error contains a detailed error.
 type Error struct {
 	// Error code.
@@ -69,7 +113,35 @@ func IsChannelDoesNotExistError(err error) bool {
 // IsFunctionExecutionFailedError returns true if the Livebox API returned a
 // "Function execution failed" error.
 func IsFunctionExecutionFailedError(err error) bool {
-	return errorMatches(err, func(err *Error) bool { return err.Description == "Function execution failed" })
+	return errorMatches(err, func(err *Error) bool {
+		return err.ErrorCode == FunctionExecutionFailedErrorCode || err.Description == "Function execution failed"
+	})
+}
+
+// IsNotFound returns true if the Livebox API returned an "Object or parameter
+// not found" error.
+func IsNotFound(err error) bool {
+	return errorMatches(err, func(err *Error) bool { return err.ErrorCode == ObjectNotFoundErrorCode })
+}
+
+// IsInvalidParameter returns true if the Livebox API rejected a parameter of
+// the request, because it is invalid or missing.
+func IsInvalidParameter(err error) bool {
+	return errorMatches(err, func(err *Error) bool {
+		return err.ErrorCode == InvalidParameterErrorCode || err.ErrorCode == MissingArgumentErrorCode
+	})
+}
+
+// IsRetryable returns true if the Livebox API returned an error that may not
+// happen again if the request is retried.
+func IsRetryable(err error) bool {
+	return IsFunctionExecutionFailedError(err)
+}
+
+// HasErrorCode returns true if the Livebox API returned an error with the
+// given code.
+func HasErrorCode(err error, code ErrorCode) bool {
+	return errorMatches(err, func(err *Error) bool { return err.ErrorCode == code })
 }
 
 func errorMatches(err error, f func(*Error) bool) bool {
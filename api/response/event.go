@@ -1,5 +1,10 @@
 package response
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // Events contain the latest events.
 type Events struct {
 	ChannelID int         `json:"channelid"`
@@ -8,6 +13,24 @@ type Events struct {
 
 type EventSpec struct {
 	Data EventData `json:"data"`
+	// Raw JSON of the event.
+	Raw json.RawMessage `json:"-"`
+	// Error that occurred while decoding the event, Raw is still set.
+	Err error `json:"-"`
+}
+
+// UnmarshalJSON decodes the event and keeps its raw JSON. An event that
+// cannot be decoded does not return an error, so that the other events of
+// the response are not lost: the error is set in Err instead.
+func (e *EventSpec) UnmarshalJSON(b []byte) error {
+	e.Raw = append(json.RawMessage(nil), b...)
+
+	type eventSpec EventSpec
+	if err := json.Unmarshal(b, (*eventSpec)(e)); err != nil {
+		e.Err = err
+	}
+
+	return nil
 }
 
 // EventSpec is an individual event.
@@ -22,8 +45,48 @@ type EventObject struct {
 	Reason     string `json:"reason"`
 }
 
-// Event is either an event or an error.
+// Event is either an event, an error, or a change of the status of the event
+// stream.
 type Event struct {
 	Event *EventData
+	// Raw JSON of the event, to archive it or to decode fields that are not
+	// part of EventData.
+	Raw   json.RawMessage
 	Error error
+	// Set when the event stream is disconnected, along with Error, and when
+	// it is reconnected if enabled with livebox.WithStreamStatusEvents.
+	Status *StreamStatus
+}
+
+// StreamState is the state of an event stream.
+type StreamState int
+
+const (
+	// StreamDisconnected means that the event stream failed, it will be
+	// reconnected after a delay.
+	StreamDisconnected StreamState = iota + 1
+	// StreamReconnected means that the event stream was reconnected after a
+	// failure. Events that occurred in the meantime may be lost.
+	StreamReconnected
+)
+
+// String returns the name of the state.
+func (s StreamState) String() string {
+	switch s {
+	case StreamDisconnected:
+		return "disconnected"
+	case StreamReconnected:
+		return "reconnected"
+	default:
+		return "unknown"
+	}
+}
+
+// StreamStatus describes a change of the status of an event stream.
+type StreamStatus struct {
+	State StreamState
+	// Number of consecutive failures.
+	Failures int
+	// Delay before the next reconnection attempt, when disconnected.
+	RetryIn time.Duration
 }
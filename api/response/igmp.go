@@ -0,0 +1,16 @@
+package response
+
+// IGMPConfig is the configuration of multicast handling.
+type IGMPConfig struct {
+	// Whether the IGMP proxy is enabled. It forwards multicast streams from
+	// the WAN to the LAN, it is required by the TV service.
+	ProxyEnable bool `json:"ProxyEnable"`
+	// IGMP version used by the proxy (2 or 3).
+	ProxyVersion int `json:"ProxyVersion"`
+	// Whether IGMP snooping is enabled. Multicast streams are only sent to
+	// the ports that subscribed to them.
+	SnoopingEnable bool `json:"SnoopingEnable"`
+	// Whether multicast streams are stopped as soon as a leave message is
+	// received.
+	FastLeave bool `json:"FastLeave"`
+}
@@ -0,0 +1,78 @@
+package response
+
+// Object is an object of the Livebox datamodel, as returned by the sysbus
+// endpoint. It describes the parameters and functions available on the
+// object, and optionally its children.
+type Object struct {
+	ObjectInfo ObjectInfo  `json:"objectInfo"`
+	Parameters []Parameter `json:"parameters"`
+	Functions  []Function  `json:"functions"`
+	Children   []Object    `json:"children"`
+}
+
+// Path returns the path of the object, it can be used as the service of a
+// request.
+func (o *Object) Path() string {
+	return o.ObjectInfo.KeyPath + o.ObjectInfo.Key
+}
+
+// ObjectInfo identifies an object of the datamodel.
+type ObjectInfo struct {
+	// Key of the object.
+	Key string `json:"key"`
+	// Name of the object.
+	Name string `json:"name"`
+	// Path of the parent of the object using keys, ending with a ".".
+	KeyPath string `json:"keyPath"`
+	// Path of the parent of the object using indexes, ending with a ".".
+	IndexPath string `json:"indexPath"`
+}
+
+// Parameter is a parameter of an object.
+type Parameter struct {
+	// Name of the parameter.
+	Name string `json:"name"`
+	// Type of the parameter (e.g. "bool", "string", "uint32").
+	Type string `json:"type"`
+	// Current value of the parameter.
+	Value any `json:"value"`
+	// Attributes of the parameter.
+	Attributes ParameterAttributes `json:"attributes"`
+}
+
+// ParameterAttributes are the attributes of a parameter.
+type ParameterAttributes struct {
+	ReadOnly   bool `json:"read_only"`
+	Persistent bool `json:"persistent"`
+	Volatile   bool `json:"volatile"`
+}
+
+// Function is a function of an object, it is called by sending a request.
+type Function struct {
+	// Name of the function, used as the method of a request.
+	Name string `json:"name"`
+	// Type of the value returned by the function.
+	Type string `json:"type"`
+	// Arguments of the function.
+	Arguments []Argument `json:"arguments"`
+}
+
+// Argument is an argument of a function.
+type Argument struct {
+	// Name of the argument.
+	Name string `json:"name"`
+	// Type of the argument.
+	Type string `json:"type"`
+	// Attributes of the argument.
+	Attributes ArgumentAttributes `json:"attributes"`
+}
+
+// ArgumentAttributes are the attributes of an argument.
+type ArgumentAttributes struct {
+	// The argument is an input parameter of the function.
+	In bool `json:"in"`
+	// The argument is returned in the "data" field of the response.
+	Out bool `json:"out"`
+	// The argument must be set.
+	Mandatory bool `json:"mandatory"`
+}
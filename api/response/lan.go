@@ -0,0 +1,15 @@
+package response
+
+// LANConfig is the IPv4 configuration of the LAN.
+type LANConfig struct {
+	// IPv4 address of the Livebox on the LAN.
+	Address string `json:"Address"`
+	// Netmask of the LAN.
+	Netmask string `json:"Netmask"`
+	// Whether the DHCP server is enabled.
+	DHCPEnable bool `json:"DHCPEnable"`
+	// First address of the DHCP pool.
+	DHCPMinAddress string `json:"DHCPMinAddress"`
+	// Last address of the DHCP pool.
+	DHCPMaxAddress string `json:"DHCPMaxAddress"`
+}
@@ -0,0 +1,86 @@
+package response
+
+import (
+	"slices"
+	"strings"
+)
+
+// NetworkInterface is a NeMo network interface: a physical port, a VLAN, a
+// bridge or a logical interface (e.g. "data" for the internet connection).
+type NetworkInterface struct {
+	// Name of the interface.
+	Name string
+	// Whether the interface is enabled.
+	Enable bool
+	// Whether the interface is up.
+	Status bool
+	// Space-separated flags of the interface (e.g. "bridge", "vlan", "iptv").
+	Flags string
+	// Interfaces this interface is built on.
+	LowerLayers []string
+	// Interfaces built on this interface.
+	UpperLayers []string
+	// VLAN ID of the interface, 0 if it is not a VLAN.
+	VLANID int
+}
+
+// HasFlag returns true if the interface has the given flag.
+func (i *NetworkInterface) HasFlag(flag string) bool {
+	return slices.Contains(strings.Fields(i.Flags), flag)
+}
+
+// InterfaceLayout is the layout of the NeMo interfaces, indexed by name.
+type InterfaceLayout map[string]*NetworkInterface
+
+// Bridges returns the bridge interfaces.
+func (l InterfaceLayout) Bridges() []*NetworkInterface {
+	return l.filter(func(intf *NetworkInterface) bool { return intf.HasFlag("bridge") })
+}
+
+// VLANs returns the VLAN interfaces.
+func (l InterfaceLayout) VLANs() []*NetworkInterface {
+	return l.filter(func(intf *NetworkInterface) bool { return intf.VLANID != 0 })
+}
+
+// VLANsFor returns the VLAN interfaces that carry the interfaces having the
+// given flag (e.g. "internet", "iptv", "voip").
+func (l InterfaceLayout) VLANsFor(flag string) []*NetworkInterface {
+	return l.filter(func(intf *NetworkInterface) bool {
+		return intf.VLANID != 0 && l.carries(intf, flag, map[string]bool{})
+	})
+}
+
+// carries returns true if intf or one of its upper layers has the flag.
+func (l InterfaceLayout) carries(intf *NetworkInterface, flag string, seen map[string]bool) bool {
+	if seen[intf.Name] {
+		return false
+	}
+
+	seen[intf.Name] = true
+
+	if intf.HasFlag(flag) {
+		return true
+	}
+
+	for _, name := range intf.UpperLayers {
+		if upper, ok := l[name]; ok && l.carries(upper, flag, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l InterfaceLayout) filter(f func(*NetworkInterface) bool) []*NetworkInterface {
+	var intfs []*NetworkInterface
+
+	for _, intf := range l {
+		if f(intf) {
+			intfs = append(intfs, intf)
+		}
+	}
+
+	slices.SortFunc(intfs, func(a, b *NetworkInterface) int { return strings.Compare(a.Name, b.Name) })
+
+	return intfs
+}
@@ -0,0 +1,32 @@
+package response
+
+// Protocols of port forwarding rules, as IP protocol numbers.
+const (
+	ProtocolTCP    = "6"
+	ProtocolUDP    = "17"
+	ProtocolTCPUDP = "6,17"
+)
+
+// PortForwardingRule is a port forwarding rule of the NAT.
+type PortForwardingRule struct {
+	// ID of the rule, prefixed with its origin (e.g. "webui_SSH").
+	ID string `json:"Id"`
+	// Origin of the rule, "webui" for the rules created by the user.
+	Origin      string `json:"Origin"`
+	Description string `json:"Description"`
+	// Whether the rule is enabled.
+	Enable bool `json:"Enable"`
+	// State of the rule (e.g. "Enabled", "Disabled", "Error").
+	Status string `json:"Status"`
+	// Protocols of the rule, see ProtocolTCP, ProtocolUDP and ProtocolTCPUDP.
+	Protocol string `json:"Protocol"`
+	// Port, or range of ports (e.g. "8000-8010"), on the WAN side.
+	ExternalPort string `json:"ExternalPort"`
+	// Port on the LAN side.
+	InternalPort string `json:"InternalPort"`
+	// LAN address to which the traffic is forwarded.
+	DestinationIPAddress string `json:"DestinationIPAddress"`
+	// Only traffic from this prefix is forwarded, all traffic is forwarded if
+	// empty.
+	SourcePrefix string `json:"SourcePrefix"`
+}
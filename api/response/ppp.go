@@ -0,0 +1,23 @@
+package response
+
+// PPPConnectionStatusConnected is the PPP connection status when the session
+// is established.
+const PPPConnectionStatusConnected = "Connected"
+
+// PPPStatus is the state of the PPP session of the WAN connection.
+type PPPStatus struct {
+	// Whether the PPP interface is enabled.
+	Enable bool `json:"Enable"`
+	// Status of the connection (e.g. "Connected", "Connecting", "Disconnected").
+	ConnectionStatus string `json:"ConnectionStatus"`
+	// Last error that occurred on the connection ("ERROR_NONE" if none).
+	LastConnectionError string `json:"LastConnectionError"`
+	// PPP username.
+	Username string `json:"Username"`
+	// Public IPv4 address obtained by the session.
+	LocalIPAddress string `json:"LocalIPAddress"`
+	// IPv4 address of the remote end of the session.
+	RemoteIPAddress string `json:"RemoteIPAddress"`
+	// DNS servers obtained by the session, comma-separated.
+	DNSServers string `json:"DNSServers"`
+}
@@ -0,0 +1,19 @@
+package response
+
+// RemoteAccess is the state of the remote web administration.
+type RemoteAccess struct {
+	// Whether remote administration is enabled.
+	Enable bool `json:"Enable"`
+	// Port on which the web interface is reachable from the internet.
+	Port int `json:"Port"`
+	// Whether the web interface is served over HTTPS.
+	SecureMode bool `json:"SecureMode"`
+	// Time in seconds after which remote administration is automatically
+	// disabled, 0 if it never expires.
+	Timeout int `json:"Timeout"`
+	// Only addresses in this prefix may connect, all addresses are allowed if
+	// empty.
+	SourcePrefix string `json:"SourcePrefix"`
+	// URL of the web interface from the internet.
+	RemoteAccessURL string `json:"RemoteAccessURL"`
+}
@@ -0,0 +1,19 @@
+package response
+
+// SambaConfig is the configuration of the Samba file server.
+type SambaConfig struct {
+	// Whether the Samba server is enabled.
+	Enable bool `json:"Enable"`
+	// Windows workgroup of the server.
+	Workgroup string `json:"Workgroup"`
+	// NetBIOS name of the server.
+	ServerName string `json:"ServerName"`
+}
+
+// DLNAConfig is the configuration of the DLNA media server.
+type DLNAConfig struct {
+	// Whether the DLNA server is enabled.
+	Enable bool `json:"Enable"`
+	// Name advertised by the DLNA server.
+	FriendlyName string `json:"FriendlyName"`
+}
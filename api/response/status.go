@@ -0,0 +1,14 @@
+package response
+
+// Status is the response of most API calls. It contains the value returned by
+// the function that was called.
+type Status[T any] struct {
+	Status T `json:"status"`
+}
+
+// StatusData is the response of API calls that also return output arguments.
+// The value returned by the function is in Status, output arguments are in Data.
+type StatusData[T, D any] struct {
+	Status T `json:"status"`
+	Data   D `json:"data"`
+}
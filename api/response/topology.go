@@ -0,0 +1,40 @@
+package response
+
+// TopologyNode is a node of the home network topology. The root node is the
+// Livebox, its children are the devices and network equipments (switches,
+// Wi-Fi extenders, ...) connected to it, and so on.
+type TopologyNode struct {
+	// Unique key of the node, usually its MAC address.
+	Key string `json:"Key"`
+	// Friendly name of the node.
+	Name string `json:"Name"`
+	// Type of the node (e.g. "SAH HGW", "Computer").
+	DeviceType string `json:"DeviceType"`
+	// Whether the node is currently connected.
+	Active bool `json:"Active"`
+	// MAC address of the node.
+	PhysAddress string `json:"PhysAddress"`
+	// Current IPv4 address of the node.
+	IPAddress string `json:"IPAddress"`
+	// Interface of the parent node this node is connected to.
+	InterfaceName string `json:"InterfaceName"`
+	// Nodes connected to this node.
+	Children []*TopologyNode `json:"Children"`
+}
+
+// Walk calls f for the node and all its descendants, depth-first. The depth of
+// the node relative to the node Walk was called on is given to f. Walk stops
+// descending into the children of a node if f returns false.
+func (n *TopologyNode) Walk(f func(node *TopologyNode, depth int) bool) {
+	n.walk(f, 0)
+}
+
+func (n *TopologyNode) walk(f func(node *TopologyNode, depth int) bool, depth int) {
+	if !f(n, depth) {
+		return
+	}
+
+	for _, child := range n.Children {
+		child.walk(f, depth+1)
+	}
+}
@@ -0,0 +1,42 @@
+package response
+
+import "strings"
+
+// IPTVStatusAvailable is the IPTV status reported when the TV service is
+// available.
+const IPTVStatusAvailable = "Available"
+
+// IPTVChannel is a WAN channel used by the TV service.
+type IPTVChannel struct {
+	// Whether the channel is up.
+	ChannelStatus bool `json:"ChannelStatus"`
+	// Type of the channel (e.g. "IPTV", "VOD").
+	ChannelType string `json:"ChannelType"`
+	// Number of the channel.
+	ChannelNumber string `json:"ChannelNumber"`
+	// Flags of the channel, "igmp" is set on multicast channels.
+	ChannelFlags string `json:"ChannelFlags"`
+}
+
+// TVStatus is the status of the Orange TV service.
+type TVStatus struct {
+	// Status of the TV subscription, IPTVStatusAvailable when it is working.
+	IPTVStatus string
+	// Whether multiple decoders can be used at the same time.
+	MultiScreens bool
+	// WAN channels used by the TV service.
+	Channels []IPTVChannel
+	// TV decoders known by the Livebox.
+	Decoders []Device
+}
+
+// MulticastUp returns true if at least one multicast channel is up.
+func (s *TVStatus) MulticastUp() bool {
+	for _, ch := range s.Channels {
+		if ch.ChannelStatus && strings.Contains(ch.ChannelFlags, "igmp") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,11 @@
+package response
+
+// User is a local user account of the Livebox.
+type User struct {
+	// Name of the user, used to log in.
+	Name string `json:"name"`
+	// Whether the account is enabled.
+	Enable bool `json:"enable"`
+	// Groups the user belongs to. They define the rights of the user.
+	Groups []string `json:"groups"`
+}
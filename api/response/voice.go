@@ -0,0 +1,116 @@
+package response
+
+import (
+	"strings"
+	"time"
+)
+
+// CallType is the outcome of a call.
+type CallType string
+
+const (
+	// CallTypeSucceeded is a call that was answered.
+	CallTypeSucceeded CallType = "succeeded"
+	// CallTypeMissed is an incoming call that was not answered.
+	CallTypeMissed CallType = "missed"
+	// CallTypeFailed is a call that could not be established.
+	CallTypeFailed CallType = "failed"
+)
+
+// CallOrigin tells which party initiated the call.
+type CallOrigin string
+
+const (
+	// CallOriginLocal is an outgoing call.
+	CallOriginLocal CallOrigin = "local"
+	// CallOriginRemote is an incoming call.
+	CallOriginRemote CallOrigin = "remote"
+)
+
+// Call is an entry of the call history.
+type Call struct {
+	// ID of the call.
+	ID string `json:"callId"`
+	// Outcome of the call.
+	Type CallType `json:"callType"`
+	// Direction of the call.
+	Origin CallOrigin `json:"callOrigin"`
+	// Phone number of the remote party. It is empty for hidden numbers.
+	RemoteNumber string `json:"remoteNumber"`
+	// Name of the remote party, if known.
+	RemoteName string `json:"remoteName"`
+	// Time at which the call started.
+	StartTime time.Time `json:"startTime"`
+	// Duration of the call, in seconds.
+	Duration int `json:"duration"`
+}
+
+// Incoming returns true if the call was initiated by the remote party.
+func (c *Call) Incoming() bool {
+	return c.Origin == CallOriginRemote
+}
+
+// VoiceTrunk is a provisioned VoIP trunk.
+type VoiceTrunk struct {
+	// Name of the trunk.
+	Name string `json:"name"`
+	// Signaling protocol of the trunk (e.g. "SIP").
+	SignalingProtocol string `json:"signalingProtocol"`
+	// Whether the trunk is enabled ("Enabled" or "Disabled").
+	Enable string `json:"enable"`
+	// SIP profile of the provider.
+	SIP SIPProfile `json:"sip"`
+	// Lines of the trunk.
+	Lines []VoiceLine `json:"trunk_lines"`
+}
+
+// SIPProfile is the SIP configuration provisioned by the provider.
+type SIPProfile struct {
+	ProxyServer         string `json:"proxyServer"`
+	RegistrarServer     string `json:"registrarServer"`
+	OutboundProxyServer string `json:"outboundProxyServer"`
+	UserAgentDomain     string `json:"userAgentDomain"`
+}
+
+// VoiceLine is a phone line of a VoIP trunk.
+type VoiceLine struct {
+	// Name of the line.
+	Name string `json:"name"`
+	// Whether the line is enabled ("Enabled" or "Disabled").
+	Enable string `json:"enable"`
+	// Registration status of the line (e.g. "Up").
+	Status string `json:"status"`
+	// Details about the status of the line.
+	StatusInfo string `json:"statusInfo"`
+	// Phone number of the line.
+	DirectoryNumber string `json:"directoryNumber"`
+	// SIP URI of the line. It is masked by the client.
+	URI string `json:"uri"`
+	// Comma-separated list of codecs enabled on the line.
+	Codecs string `json:"codecList"`
+}
+
+// MaskSIPURI masks the user part of a SIP URI, only its last two characters are
+// kept. For instance "sip:+33123456789@domain" becomes "sip:*********89@domain".
+func MaskSIPURI(uri string) string {
+	scheme, rest, ok := strings.Cut(uri, ":")
+	if !ok {
+		scheme, rest = "", uri
+	}
+
+	user, host, _ := strings.Cut(rest, "@")
+	if len(user) > 2 {
+		user = strings.Repeat("*", len(user)-2) + user[len(user)-2:]
+	}
+
+	masked := user
+	if host != "" {
+		masked += "@" + host
+	}
+
+	if scheme != "" {
+		masked = scheme + ":" + masked
+	}
+
+	return masked
+}
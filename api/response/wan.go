@@ -0,0 +1,66 @@
+package response
+
+import "strings"
+
+// AccessType is the access technology of the WAN connection.
+type AccessType string
+
+const (
+	AccessTypeFTTH     AccessType = "FTTH"
+	AccessTypeVDSL     AccessType = "VDSL"
+	AccessTypeADSL     AccessType = "ADSL"
+	AccessTypeEthernet AccessType = "Ethernet"
+	AccessTypeUnknown  AccessType = "Unknown"
+)
+
+// WANStatus is the status of the WAN connection.
+type WANStatus struct {
+	// Physical link type (e.g. "gpon", "vdsl", "adsl", "ethernet").
+	LinkType string `json:"LinkType"`
+	// State of the physical link ("up" or "down").
+	LinkState string `json:"LinkState"`
+	// Protocol used on the link (e.g. "dhcp", "ppp").
+	Protocol string `json:"Protocol"`
+	// State of the connection (e.g. "Bound", "Connected").
+	ConnectionState string `json:"ConnectionState"`
+	// Last error that occurred on the connection.
+	LastConnectionError string `json:"LastConnectionError"`
+	// Public IPv4 address.
+	IPAddress string `json:"IPAddress"`
+	// IPv4 address of the gateway of the ISP.
+	RemoteGateway string `json:"RemoteGateway"`
+	// DNS servers, comma-separated.
+	DNSServers string `json:"DNSServers"`
+	// Public IPv6 address.
+	IPv6Address string `json:"IPv6Address"`
+	// MAC address of the WAN interface.
+	MACAddress string `json:"MACAddress"`
+}
+
+// WANMode is the mode of the WAN connection.
+type WANMode struct {
+	// Mode of the WAN connection (e.g. "GPON_DHCP", "VDSL_PPP").
+	Mode string
+	// Access technology, derived from the mode.
+	AccessType AccessType
+}
+
+// NewWANMode returns the WANMode matching the mode reported by the Livebox.
+func NewWANMode(mode string) *WANMode {
+	prefix, _, _ := strings.Cut(strings.ToUpper(mode), "_")
+
+	accessType := AccessTypeUnknown
+
+	switch prefix {
+	case "GPON", "XGSPON", "FTTH":
+		accessType = AccessTypeFTTH
+	case "VDSL":
+		accessType = AccessTypeVDSL
+	case "ADSL":
+		accessType = AccessTypeADSL
+	case "ETHERNET":
+		accessType = AccessTypeEthernet
+	}
+
+	return &WANMode{Mode: mode, AccessType: accessType}
+}
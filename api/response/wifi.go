@@ -0,0 +1,45 @@
+package response
+
+import "strings"
+
+// WiFiStatus is the global state of the Wi-Fi of the Livebox.
+type WiFiStatus struct {
+	// Whether the Wi-Fi is enabled.
+	Enable bool `json:"Enable"`
+	// Whether the Wi-Fi is up.
+	Status bool `json:"Status"`
+}
+
+// WiFiAccessPoint is a Wi-Fi access point of the Livebox. There is usually
+// one access point per band, and additional ones for the guest network.
+type WiFiAccessPoint struct {
+	// Name of the interface of the access point (e.g. "wl0", "wlguest2").
+	Name string `json:"Name"`
+	SSID string `json:"SSID"`
+	// MAC address of the access point.
+	BSSID string `json:"BSSID"`
+	// State of the access point, "Up" or "Down".
+	VAPStatus string `json:"VAPStatus"`
+	// Whether the SSID is broadcast.
+	SSIDAdvertisementEnabled bool `json:"SSIDAdvertisementEnabled"`
+	// Security of the access point. The passphrase is not included.
+	Security WiFiSecurity `json:"Security"`
+	// WPS configuration of the access point.
+	WPS WiFiWPS `json:"WPS"`
+}
+
+// Guest returns whether the access point belongs to the guest network.
+func (ap *WiFiAccessPoint) Guest() bool {
+	return strings.HasPrefix(ap.Name, "wlguest")
+}
+
+// WiFiSecurity is the security configuration of a Wi-Fi access point.
+type WiFiSecurity struct {
+	// Security mode (e.g. "WPA2-Personal", "WPA2-WPA3-Personal").
+	ModeEnabled string `json:"ModeEnabled"`
+}
+
+// WiFiWPS is the WPS configuration of a Wi-Fi access point.
+type WiFiWPS struct {
+	Enable bool `json:"Enable"`
+}
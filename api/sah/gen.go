@@ -0,0 +1,106 @@
+//go:build ignore
+
+// This program generates methods.go from methods.txt.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	f, err := os.Open("methods.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	services := map[string][]string{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			log.Fatalf("invalid line %q", line)
+		}
+
+		services[fields[0]] = append(services[fields[0]], fields[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	names := make([]string, 0, len(services))
+	for service := range services {
+		names = append(names, service)
+	}
+
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by gen.go; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package sah")
+
+	for _, service := range names {
+		methods := services[service]
+		sort.Strings(methods)
+
+		fmt.Fprintln(&buf)
+		fmt.Fprintf(&buf, "// %s contains the methods of the %q service.\n", identifier(service), service)
+		fmt.Fprintf(&buf, "var %s = struct {\n", identifier(service))
+
+		for _, method := range methods {
+			fmt.Fprintf(&buf, "%s Method\n", identifier(method))
+		}
+
+		fmt.Fprintln(&buf, "}{")
+
+		for _, method := range methods {
+			fmt.Fprintf(&buf, "%s: Method{Service: %q, Name: %q},\n", identifier(method), service, method)
+		}
+
+		fmt.Fprintln(&buf, "}")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("methods.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// identifier returns an exported Go identifier for a service or method name,
+// e.g. "NMC.OrangeTV" becomes "NMCOrangeTV".
+func identifier(name string) string {
+	var b strings.Builder
+
+	for _, part := range strings.Split(name, ".") {
+		r := []rune(part)
+		if len(r) == 0 {
+			continue
+		}
+
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,252 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package sah
+
+// DHCPv4ServerPoolDefault contains the methods of the "DHCPv4.Server.Pool.default" service.
+var DHCPv4ServerPoolDefault = struct {
+	AddStaticLease    Method
+	DeleteStaticLease Method
+	GetLeases         Method
+	GetStaticLeases   Method
+}{
+	AddStaticLease:    Method{Service: "DHCPv4.Server.Pool.default", Name: "addStaticLease"},
+	DeleteStaticLease: Method{Service: "DHCPv4.Server.Pool.default", Name: "deleteStaticLease"},
+	GetLeases:         Method{Service: "DHCPv4.Server.Pool.default", Name: "getLeases"},
+	GetStaticLeases:   Method{Service: "DHCPv4.Server.Pool.default", Name: "getStaticLeases"},
+}
+
+// DLNA contains the methods of the "DLNA" service.
+var DLNA = struct {
+	Get Method
+	Set Method
+}{
+	Get: Method{Service: "DLNA", Name: "get"},
+	Set: Method{Service: "DLNA", Name: "set"},
+}
+
+// DeviceInfo contains the methods of the "DeviceInfo" service.
+var DeviceInfo = struct {
+	Get Method
+}{
+	Get: Method{Service: "DeviceInfo", Name: "get"},
+}
+
+// Devices contains the methods of the "Devices" service.
+var Devices = struct {
+	DestroyDevice Method
+	Get           Method
+}{
+	DestroyDevice: Method{Service: "Devices", Name: "destroyDevice"},
+	Get:           Method{Service: "Devices", Name: "get"},
+}
+
+// Firewall contains the methods of the "Firewall" service.
+var Firewall = struct {
+	DeletePortForwarding Method
+	GetPortForwarding    Method
+	SetPortForwarding    Method
+}{
+	DeletePortForwarding: Method{Service: "Firewall", Name: "deletePortForwarding"},
+	GetPortForwarding:    Method{Service: "Firewall", Name: "getPortForwarding"},
+	SetPortForwarding:    Method{Service: "Firewall", Name: "setPortForwarding"},
+}
+
+// HomeLan contains the methods of the "HomeLan" service.
+var HomeLan = struct {
+	GetDevicesResults Method
+}{
+	GetDevicesResults: Method{Service: "HomeLan", Name: "getDevicesResults"},
+}
+
+// IGMPProxy contains the methods of the "IGMPProxy" service.
+var IGMPProxy = struct {
+	Get Method
+	Set Method
+}{
+	Get: Method{Service: "IGMPProxy", Name: "get"},
+	Set: Method{Service: "IGMPProxy", Name: "set"},
+}
+
+// IPPingDiagnostics contains the methods of the "IPPingDiagnostics" service.
+var IPPingDiagnostics = struct {
+	ExecDiagnostic Method
+}{
+	ExecDiagnostic: Method{Service: "IPPingDiagnostics", Name: "execDiagnostic"},
+}
+
+// IoTService contains the methods of the "IoTService" service.
+var IoTService = struct {
+	GetStatus Method
+}{
+	GetStatus: Method{Service: "IoTService", Name: "getStatus"},
+}
+
+// NMC contains the methods of the "NMC" service.
+var NMC = struct {
+	CheckForUpgrades Method
+	Get              Method
+	GetLANIP         Method
+	GetWANStatus     Method
+	Reboot           Method
+	SetLANIP         Method
+}{
+	CheckForUpgrades: Method{Service: "NMC", Name: "checkForUpgrades"},
+	Get:              Method{Service: "NMC", Name: "get"},
+	GetLANIP:         Method{Service: "NMC", Name: "getLANIP"},
+	GetWANStatus:     Method{Service: "NMC", Name: "getWANStatus"},
+	Reboot:           Method{Service: "NMC", Name: "reboot"},
+	SetLANIP:         Method{Service: "NMC", Name: "setLANIP"},
+}
+
+// NMCGuest contains the methods of the "NMC.Guest" service.
+var NMCGuest = struct {
+	Get Method
+	Set Method
+}{
+	Get: Method{Service: "NMC.Guest", Name: "get"},
+	Set: Method{Service: "NMC.Guest", Name: "set"},
+}
+
+// NMCNetworkConfig contains the methods of the "NMC.NetworkConfig" service.
+var NMCNetworkConfig = struct {
+	ExportConfig Method
+	ImportConfig Method
+}{
+	ExportConfig: Method{Service: "NMC.NetworkConfig", Name: "exportConfig"},
+	ImportConfig: Method{Service: "NMC.NetworkConfig", Name: "importConfig"},
+}
+
+// NMCOrangeTV contains the methods of the "NMC.OrangeTV" service.
+var NMCOrangeTV = struct {
+	GetIPTVConfig       Method
+	GetIPTVMultiScreens Method
+	GetIPTVStatus       Method
+}{
+	GetIPTVConfig:       Method{Service: "NMC.OrangeTV", Name: "getIPTVConfig"},
+	GetIPTVMultiScreens: Method{Service: "NMC.OrangeTV", Name: "getIPTVMultiScreens"},
+	GetIPTVStatus:       Method{Service: "NMC.OrangeTV", Name: "getIPTVStatus"},
+}
+
+// NMCReboot contains the methods of the "NMC.Reboot" service.
+var NMCReboot = struct {
+	GetHistory Method
+}{
+	GetHistory: Method{Service: "NMC.Reboot", Name: "getHistory"},
+}
+
+// NMCWifi contains the methods of the "NMC.Wifi" service.
+var NMCWifi = struct {
+	Get          Method
+	Set          Method
+	StartPairing Method
+}{
+	Get:          Method{Service: "NMC.Wifi", Name: "get"},
+	Set:          Method{Service: "NMC.Wifi", Name: "set"},
+	StartPairing: Method{Service: "NMC.Wifi", Name: "startPairing"},
+}
+
+// NeMoIntfData contains the methods of the "NeMo.Intf.data" service.
+var NeMoIntfData = struct {
+	GetMIBs           Method
+	SetFirstParameter Method
+}{
+	GetMIBs:           Method{Service: "NeMo.Intf.data", Name: "getMIBs"},
+	SetFirstParameter: Method{Service: "NeMo.Intf.data", Name: "setFirstParameter"},
+}
+
+// NeMoIntfLan contains the methods of the "NeMo.Intf.lan" service.
+var NeMoIntfLan = struct {
+	GetMIBs       Method
+	SetWLANConfig Method
+}{
+	GetMIBs:       Method{Service: "NeMo.Intf.lan", Name: "getMIBs"},
+	SetWLANConfig: Method{Service: "NeMo.Intf.lan", Name: "setWLANConfig"},
+}
+
+// OrangeRemoteAccess contains the methods of the "OrangeRemoteAccess" service.
+var OrangeRemoteAccess = struct {
+	Get Method
+	Set Method
+}{
+	Get: Method{Service: "OrangeRemoteAccess", Name: "get"},
+	Set: Method{Service: "OrangeRemoteAccess", Name: "set"},
+}
+
+// RemoteAccess contains the methods of the "RemoteAccess" service.
+var RemoteAccess = struct {
+	Disable Method
+	Enable  Method
+	Get     Method
+}{
+	Disable: Method{Service: "RemoteAccess", Name: "disable"},
+	Enable:  Method{Service: "RemoteAccess", Name: "enable"},
+	Get:     Method{Service: "RemoteAccess", Name: "get"},
+}
+
+// Samba contains the methods of the "Samba" service.
+var Samba = struct {
+	Get Method
+	Set Method
+}{
+	Get: Method{Service: "Samba", Name: "get"},
+	Set: Method{Service: "Samba", Name: "set"},
+}
+
+// Scheduler contains the methods of the "Scheduler" service.
+var Scheduler = struct {
+	AddSchedule          Method
+	GetCompleteSchedules Method
+	OverrideSchedule     Method
+}{
+	AddSchedule:          Method{Service: "Scheduler", Name: "addSchedule"},
+	GetCompleteSchedules: Method{Service: "Scheduler", Name: "getCompleteSchedules"},
+	OverrideSchedule:     Method{Service: "Scheduler", Name: "overrideSchedule"},
+}
+
+// TopologyDiagnostics contains the methods of the "TopologyDiagnostics" service.
+var TopologyDiagnostics = struct {
+	BuildTopology Method
+}{
+	BuildTopology: Method{Service: "TopologyDiagnostics", Name: "buildTopology"},
+}
+
+// TraceRouteDiagnostics contains the methods of the "TraceRouteDiagnostics" service.
+var TraceRouteDiagnostics = struct {
+	ExecDiagnostic Method
+}{
+	ExecDiagnostic: Method{Service: "TraceRouteDiagnostics", Name: "execDiagnostic"},
+}
+
+// UserManagement contains the methods of the "UserManagement" service.
+var UserManagement = struct {
+	GetUsers Method
+}{
+	GetUsers: Method{Service: "UserManagement", Name: "getUsers"},
+}
+
+// VoiceServiceVoiceApplication contains the methods of the "VoiceService.VoiceApplication" service.
+var VoiceServiceVoiceApplication = struct {
+	ClearCallList Method
+	GetCallList   Method
+	ListTrunks    Method
+}{
+	ClearCallList: Method{Service: "VoiceService.VoiceApplication", Name: "clearCallList"},
+	GetCallList:   Method{Service: "VoiceService.VoiceApplication", Name: "getCallList"},
+	ListTrunks:    Method{Service: "VoiceService.VoiceApplication", Name: "listTrunks"},
+}
+
+// WOL contains the methods of the "WOL" service.
+var WOL = struct {
+	SendWakeOnLan Method
+}{
+	SendWakeOnLan: Method{Service: "WOL", Name: "sendWakeOnLan"},
+}
+
+// SahDeviceInformation contains the methods of the "sah.Device.Information" service.
+var SahDeviceInformation = struct {
+	CreateContext  Method
+	ReleaseContext Method
+}{
+	CreateContext:  Method{Service: "sah.Device.Information", Name: "createContext"},
+	ReleaseContext: Method{Service: "sah.Device.Information", Name: "releaseContext"},
+}
@@ -0,0 +1,29 @@
+// Package sah contains the services and methods known to be available on the
+// Livebox API, so that requests can be created without string literals:
+//
+//	req := sah.NMC.GetWANStatus.Request(nil)
+//
+// Services whose name contains a dynamic part, such as "NeMo.Intf.<name>" or
+// "Devices.Device.<key>", are only listed for their most common instances.
+package sah
+
+//go:generate go run gen.go
+
+import "github.com/Tomy2e/livebox-api-client/api/request"
+
+// Method is a method of a service of the Livebox API.
+type Method struct {
+	Service string
+	Name    string
+}
+
+// Request returns a new request that calls the method with the given
+// parameters.
+func (m Method) Request(params request.Parameters) *request.Request {
+	return request.New(m.Service, m.Name, params)
+}
+
+// String returns the method in the "service:method" format.
+func (m Method) String() string {
+	return m.Service + ":" + m.Name
+}
@@ -0,0 +1,31 @@
+package livebox
+
+import "github.com/Tomy2e/livebox-api-client/internal/client"
+
+// APIVersion is the version of the Livebox API.
+type APIVersion = client.APIVersion
+
+const (
+	// APIVersionAuto detects the API version during the first login: the
+	// current API is tried first, then the legacy one.
+	APIVersionAuto = client.APIVersionAuto
+	// APIVersion4 is the API of current firmwares (Livebox 4 and newer).
+	APIVersion4 = client.APIVersion4
+	// APIVersion1 is the legacy API of older firmwares (Livebox 3 and early
+	// Livebox 4 firmwares). Events are not supported with this version.
+	APIVersion1 = client.APIVersion1
+)
+
+// WithAPIVersion sets the version of the API used to communicate with the
+// Livebox. Defaults to APIVersionAuto.
+func WithAPIVersion(version APIVersion) Opt {
+	return func(c *clientOpts) {
+		c.apiVersion = version
+	}
+}
+
+// APIVersion returns the version of the API used by the client. It returns
+// APIVersionAuto if the version is not detected yet.
+func (c *Client) APIVersion() APIVersion {
+	return c.client.APIVersion()
+}
@@ -0,0 +1,68 @@
+package livebox
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+)
+
+// DefaultBatchWorkers is the default maximum number of requests sent
+// concurrently by Client.Batch.
+const DefaultBatchWorkers = 4
+
+// WithBatchWorkers sets the maximum number of requests sent concurrently by
+// Client.Batch. If not used, DefaultBatchWorkers is used.
+func WithBatchWorkers(workers int) Opt {
+	return func(c *clientOpts) {
+		c.batchWorkers = workers
+	}
+}
+
+// BatchResult is the result of a request sent by Client.Batch.
+type BatchResult struct {
+	Request *request.Request
+	// Raw response of the request, nil if Err is not nil.
+	Response json.RawMessage
+	Err      error
+}
+
+// Unmarshal unmarshals the response of the request into out, or returns the
+// error of the request.
+func (r *BatchResult) Unmarshal(out any) error {
+	if r.Err != nil {
+		return r.Err
+	}
+
+	return json.Unmarshal(r.Response, out)
+}
+
+// Batch sends the requests concurrently, with at most the number of workers
+// set with WithBatchWorkers. The results are returned in the order of the
+// requests. A failed request does not cancel the other ones.
+func (c *Client) Batch(ctx context.Context, reqs ...*request.Request) []BatchResult {
+	results := make([]BatchResult, len(reqs))
+	sem := make(chan struct{}, c.batchWorkers)
+
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		results[i].Request = req
+
+		wg.Add(1)
+		go func(r *BatchResult) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := c.Request(ctx, r.Request, &r.Response); err != nil {
+				r.Response = nil
+				r.Err = err
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results
+}
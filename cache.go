@@ -0,0 +1,293 @@
+package livebox
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+)
+
+// WithCache enables a read-through cache of the responses of read requests
+// (see WithSingleFlight), to protect the Livebox from high-frequency pollers.
+// Responses are cached for ttl, unless another TTL is set for the method with
+// WithCacheTTL. Errors are never cached.
+//
+// A request that is not a read request invalidates the cached responses of
+// its service, and of the services it is known to affect: for instance,
+// ReconnectWAN invalidates the cached responses of GetWANStatus. The cache can
+// also be invalidated with Client.InvalidateCache. Expired responses are
+// removed periodically.
+func WithCache(ttl time.Duration) Opt {
+	return func(c *clientOpts) {
+		c.cacheTTL = ttl
+	}
+}
+
+// WithCacheTTL overrides the TTL of the cached responses of a method, given
+// in the "service:method" format (see sah.Method.String). Responses of the
+// method are not cached if ttl is 0. It has no effect unless WithCache is used.
+func WithCacheTTL(method string, ttl time.Duration) Opt {
+	return func(c *clientOpts) {
+		if c.cacheTTLs == nil {
+			c.cacheTTLs = map[string]time.Duration{}
+		}
+		c.cacheTTLs[method] = ttl
+	}
+}
+
+// InvalidateCache removes the cached responses of the given methods, in the
+// "service:method" format. All cached responses are removed if no method is
+// given. It does nothing if the cache is not enabled.
+func (c *Client) InvalidateCache(methods ...string) {
+	if c.cache == nil {
+		return
+	}
+
+	if len(methods) == 0 {
+		c.cache.invalidateAll()
+		return
+	}
+
+	c.cache.invalidate(func(e *cacheEntry) bool {
+		for _, m := range methods {
+			if e.method == m {
+				return true
+			}
+		}
+
+		return false
+	}, servicesOf(methods)...)
+}
+
+// cacheDependencies lists the services whose responses are affected by a
+// method, in the "service:method" format, in addition to its own service.
+var cacheDependencies = map[string][]string{
+	// Used by ReconnectWAN, changes the WAN status.
+	"NeMo.Intf.data:setFirstParameter": {"NMC"},
+	"NeMo.Intf.lan:setWLANConfig":      {"NMC.Wifi", "NMC.Guest"},
+	"NMC.Wifi:set":                     {"NeMo.Intf.lan"},
+	"NMC.Guest:set":                    {"NeMo.Intf.lan", "NMC.Wifi"},
+	"NMC:setLANIP":                     {"DHCPv4.Server.Pool.default", "NeMo.Intf.lan"},
+	"Devices:destroyDevice":            {"Devices.Device."},
+}
+
+// affectedServices returns the services whose responses are affected by a
+// request to method, in the "service:method" format.
+func affectedServices(method string) []string {
+	return append(servicesOf([]string{method}), cacheDependencies[method]...)
+}
+
+// servicesOf returns the services of methods in the "service:method" format.
+func servicesOf(methods []string) []string {
+	services := make([]string, len(methods))
+	for i, m := range methods {
+		services[i], _, _ = strings.Cut(m, ":")
+	}
+
+	return services
+}
+
+// matchService returns true if service matches pattern. Patterns ending with
+// a dot match the services they prefix.
+func matchService(pattern, service string) bool {
+	return pattern == service || strings.HasSuffix(pattern, ".") && strings.HasPrefix(service, pattern)
+}
+
+// cacheEntry is a cached response.
+type cacheEntry struct {
+	service string
+	method  string
+	res     json.RawMessage
+	expires time.Time
+}
+
+// responseCache caches the responses of read requests.
+type responseCache struct {
+	ttl  time.Duration
+	ttls map[string]time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	// Time at which the expired entries were last removed.
+	swept time.Time
+	// Incremented when the responses of a service, or of the services it
+	// prefixes, are invalidated. Responses fetched while a service was
+	// invalidated are not cached.
+	generations map[string]uint64
+	// Incremented when all the responses are invalidated.
+	epoch uint64
+}
+
+// newResponseCache returns a new cache.
+func newResponseCache(ttl time.Duration, ttls map[string]time.Duration) *responseCache {
+	return &responseCache{
+		ttl:         ttl,
+		ttls:        ttls,
+		entries:     map[string]*cacheEntry{},
+		generations: map[string]uint64{},
+	}
+}
+
+// ttlOf returns the TTL of the responses of a method.
+func (rc *responseCache) ttlOf(method string) time.Duration {
+	if ttl, ok := rc.ttls[method]; ok {
+		return ttl
+	}
+
+	return rc.ttl
+}
+
+// invalidate removes the entries matched by f, and invalidates the responses
+// of services that are being fetched.
+func (rc *responseCache) invalidate(f func(e *cacheEntry) bool, services ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, service := range services {
+		rc.generations[service]++
+	}
+
+	for key, e := range rc.entries {
+		if f(e) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// invalidateServices removes the entries of services, which may be prefixes.
+func (rc *responseCache) invalidateServices(services []string) {
+	rc.invalidate(func(e *cacheEntry) bool {
+		for _, service := range services {
+			if matchService(service, e.service) {
+				return true
+			}
+		}
+
+		return false
+	}, services...)
+}
+
+// invalidateAll removes all the entries, and invalidates all the responses
+// that are being fetched.
+func (rc *responseCache) invalidateAll() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.epoch++
+	clear(rc.entries)
+}
+
+// generation returns a value that changes each time the responses of service
+// are invalidated.
+func (rc *responseCache) generation(service string) uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.generationLocked(service)
+}
+
+// generationLocked is generation, rc.mu must be held.
+func (rc *responseCache) generationLocked(service string) uint64 {
+	gen := rc.epoch
+	for pattern, g := range rc.generations {
+		if matchService(pattern, service) {
+			gen += g
+		}
+	}
+
+	return gen
+}
+
+// get returns the cached response for key, if it did not expire.
+func (rc *responseCache) get(key string) (json.RawMessage, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	e, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(e.expires) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+
+	return e.res, true
+}
+
+// put adds a response to the cache, unless the responses of its service were
+// invalidated since gen was returned by generation: the response may predate
+// a write. Expired entries are removed at most once per default TTL, so that
+// responses that are never requested again do not accumulate.
+func (rc *responseCache) put(key string, e *cacheEntry, gen uint64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.generationLocked(e.service) != gen {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(rc.swept) >= rc.ttl {
+		for k, e := range rc.entries {
+			if now.After(e.expires) {
+				delete(rc.entries, k)
+			}
+		}
+
+		rc.swept = now
+	}
+
+	rc.entries[key] = e
+}
+
+// interceptor returns an interceptor that serves read requests from the cache.
+func (rc *responseCache) interceptor(next RoundTripFunc) RoundTripFunc {
+	return func(ctx context.Context, req *request.Request, out any, opts ...request.Option) error {
+		method := req.Service + ":" + req.Method
+
+		if !isReadMethod(req.Method) {
+			// Responses fetched while the request is sent may or may not
+			// reflect it: they are invalidated again once it returns.
+			services := affectedServices(method)
+			rc.invalidateServices(services)
+			defer rc.invalidateServices(services)
+
+			return next(ctx, req, out, opts...)
+		}
+
+		ttl := rc.ttlOf(method)
+		if len(opts) > 0 || ttl <= 0 {
+			return next(ctx, req, out, opts...)
+		}
+
+		key, err := json.Marshal(req)
+		if err != nil {
+			return next(ctx, req, out, opts...)
+		}
+
+		if res, ok := rc.get(string(key)); ok {
+			return json.Unmarshal(res, out)
+		}
+
+		gen := rc.generation(req.Service)
+
+		var res json.RawMessage
+		if err := next(ctx, req, &res); err != nil {
+			return err
+		}
+
+		rc.put(string(key), &cacheEntry{
+			service: req.Service,
+			method:  method,
+			res:     res,
+			expires: time.Now().Add(ttl),
+		}, gen)
+
+		return json.Unmarshal(res, out)
+	}
+}
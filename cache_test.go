@@ -0,0 +1,122 @@
+package livebox_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+// countingHandler returns a handler that accepts any request, and counts the
+// calls to each method. If block is not nil, the first call to
+// NMC:getWANStatus signals started and waits for block to be closed.
+func countingHandler(calls map[string]int, mu *sync.Mutex, started, block chan struct{}) *apiHandler {
+	return &apiHandler{respond: func(w http.ResponseWriter, call *apiCall) {
+		method := call.Service + ":" + call.Method
+
+		mu.Lock()
+		calls[method]++
+		first := calls[method] == 1
+		mu.Unlock()
+
+		if method == "NMC:getWANStatus" && first && block != nil {
+			close(started)
+			<-block
+		}
+
+		_, _ = w.Write([]byte(`{"status":true}`))
+	}}
+}
+
+func newCachingClient(t *testing.T, address string) *livebox.Client {
+	t.Helper()
+
+	c, err := livebox.NewClient("password",
+		livebox.WithAddress(address),
+		livebox.WithCache(time.Hour),
+		livebox.WithoutKeepAlive(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestCacheInvalidatesDependencies(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+
+	srv := httptest.NewServer(countingHandler(calls, &mu, nil, nil))
+	defer srv.Close()
+
+	c := newCachingClient(t, srv.URL)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := c.GetWANStatus(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := c.ReconnectWAN(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetWANStatus(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if n := calls["NMC:getWANStatus"]; n != 2 {
+		t.Errorf("expected ReconnectWAN to invalidate the WAN status, got %d requests", n)
+	}
+}
+
+func TestCacheReadDuringWrite(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	started, block := make(chan struct{}), make(chan struct{})
+
+	srv := httptest.NewServer(countingHandler(calls, &mu, started, block))
+	defer srv.Close()
+
+	c := newCachingClient(t, srv.URL)
+	ctx := context.Background()
+
+	read := make(chan error)
+	go func() {
+		_, err := c.GetWANStatus(ctx)
+		read <- err
+	}()
+
+	// The read is in flight, it fetches the state from before the write.
+	<-started
+
+	if err := c.ReconnectWAN(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	close(block)
+
+	if err := <-read; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetWANStatus(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if n := calls["NMC:getWANStatus"]; n != 2 {
+		t.Errorf("expected the response read during the write not to be cached, got %d requests", n)
+	}
+}
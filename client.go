@@ -1,11 +1,15 @@
 package livebox
 
 import (
+	"context"
+	"crypto/tls"
 	"io"
 	"log/slog"
 	"net/http"
-	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/Tomy2e/livebox-api-client/api/sah"
 	"github.com/Tomy2e/livebox-api-client/internal/client"
 )
 
@@ -18,54 +22,211 @@ const (
 // the login or password is invalid.
 var ErrInvalidCredentials = client.ErrInvalidCredentials
 
+// ErrInsufficientPermissions is returned when a request is denied while the
+// session is valid: it was just created, or the keep-alive probe is still
+// accepted. It happens when authenticated as a user that is not an
+// administrator, see SessionInfo to get the groups granted to the user.
+var ErrInsufficientPermissions = client.ErrInsufficientPermissions
+
+// ErrTooManyLoginAttempts is returned when the login is not successful because
+// of too many failed attempts.
+var ErrTooManyLoginAttempts = client.ErrTooManyLoginAttempts
+
+// ErrUnsupportedAuthentication is returned when the Livebox does not support
+// the authentication scheme of the client, see WithAPIVersion.
+var ErrUnsupportedAuthentication = client.ErrUnsupportedAuthentication
+
+// LoginError is returned when the Livebox refuses the login. It matches
+// ErrInvalidCredentials, ErrTooManyLoginAttempts or
+// ErrUnsupportedAuthentication with errors.Is.
+type LoginError = client.LoginError
+
 // Client is a Livebox API Client. Requests sent using a client will be automatically
 // authenticated using the specified password. Client is thread safe.
 type Client struct {
 	client *client.Client
 	log    *slog.Logger
+	retry  *RetryPolicy
+	// nil if requests are not rate limited.
+	rateLimiter *rateLimiter
+	// Sends requests through the interceptors.
+	roundTrip RoundTripFunc
+	metrics   *metrics
+	// nil if the cache is not enabled.
+	cache        *responseCache
+	batchWorkers int
+	// Timeout of requests without deadline, 0 if none.
+	requestTimeout time.Duration
+	// Last request ID.
+	requestID atomic.Uint64
+
+	// Multiplexes the event listeners.
+	events          eventMux
+	eventBufferSize int
+	eventOverflow   OverflowPolicy
+	// Reconnection of event streams.
+	eventReconnect     RetryPolicy
+	streamStatusEvents bool
+	// nil if events are not deduplicated.
+	eventDedup *eventDeduper
 
-	// Events keep-alive.
-	mu           sync.Mutex
-	eventsCtr    uint64
-	eventsStopCh chan<- struct{}
+	// Set when the client is closed.
+	closed        atomic.Bool
+	logoutOnClose bool
+	// The HTTP client is shared with the other clients of a fleet, its idle
+	// connections are not closed by Close.
+	sharedHTTPClient bool
+
+	// Keeps the session alive while events are watched.
+	keepAlive keepAlive
+	// nil if the session is not persisted.
+	sessionStore SessionStore
 }
 
 // NewClient returns a new Client that will be authenticated using the given password.
+// The password is ignored if a CredentialsProvider is set with
+// WithCredentialsProvider.
 func NewClient(password string, opts ...Opt) (*Client, error) {
 	co := newClientOpts(opts)
 
-	c, err := client.New(co.httpClient, co.address, co.username, password)
+	credentials := co.credentials
+	if credentials == nil {
+		credentials = StaticCredentials(co.username, password)
+	}
+
+	c, err := client.New(co.httpClient, co.address, co.basePath, credentials.Credentials)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		client: c,
-		log:    co.log,
-	}, nil
+	c.SetAPIVersion(co.apiVersion)
+	c.SetSessionProbe(co.keepAliveProbe.Request(nil))
+	c.SetEventHTTPClient(co.eventHTTPClient)
+
+	lc := &Client{
+		client:             c,
+		log:                co.log,
+		retry:              co.retry,
+		rateLimiter:        co.rateLimiter,
+		metrics:            newMetrics(),
+		batchWorkers:       co.batchWorkers,
+		requestTimeout:     co.requestTimeout,
+		eventBufferSize:    co.eventBufferSize,
+		eventOverflow:      co.eventOverflow,
+		eventReconnect:     co.eventReconnect,
+		streamStatusEvents: co.streamStatusEvents,
+		keepAlive: keepAlive{
+			interval: co.keepAliveInterval,
+			probe:    co.keepAliveProbe,
+		},
+		sessionStore:     co.sessionStore,
+		eventDedup:       newEventDeduper(co.eventDedup),
+		logoutOnClose:    co.logoutOnClose,
+		sharedHTTPClient: co.shared,
+	}
+
+	if lc.sessionStore != nil {
+		lc.restoreSession(context.Background(), credentials)
+		c.OnSession(func() { lc.saveSession(context.Background()) })
+	}
+
+	interceptors := co.interceptors
+	if co.cacheTTL > 0 {
+		lc.cache = newResponseCache(co.cacheTTL, co.cacheTTLs)
+		interceptors = append(interceptors, lc.cache.interceptor)
+	}
+	if co.singleFlight {
+		g := &flightGroup{timeout: co.requestTimeout}
+		if g.timeout <= 0 {
+			g.timeout = DefaultSingleFlightTimeout
+		}
+
+		interceptors = append(interceptors, g.interceptor)
+	}
+
+	lc.roundTrip = chain(lc.send, interceptors)
+
+	return lc, nil
 }
 
 // clientOpts contain client custom options.
 type clientOpts struct {
-	address    string
-	username   string
-	httpClient *http.Client
-	log        *slog.Logger
+	address            string
+	basePath           string
+	username           string
+	httpClient         *http.Client
+	eventHTTPClient    *http.Client
+	log                *slog.Logger
+	retry              *RetryPolicy
+	rateLimiter        *rateLimiter
+	interceptors       []Interceptor
+	credentials        CredentialsProvider
+	tlsConfig          *tls.Config
+	apiVersion         APIVersion
+	singleFlight       bool
+	cacheTTL           time.Duration
+	cacheTTLs          map[string]time.Duration
+	batchWorkers       int
+	debugWriter        io.Writer
+	requestTimeout     time.Duration
+	eventBufferSize    int
+	eventOverflow      OverflowPolicy
+	eventReconnect     RetryPolicy
+	streamStatusEvents bool
+	keepAliveInterval  time.Duration
+	keepAliveProbe     sah.Method
+	sessionStore       SessionStore
+	eventDedup         time.Duration
+	logoutOnClose      bool
+	// HTTP client shared by the clients of a fleet.
+	sharedHTTPClient *http.Client
+	// Set by newClientOpts if httpClient is sharedHTTPClient.
+	shared bool
 }
 
 // newClientOpts returns a clientOpts object with the custom options.
 // If an option was not specified, the default value for this option is used.
 func newClientOpts(opts []Opt) *clientOpts {
 	co := &clientOpts{
-		httpClient: http.DefaultClient,
-		address:    DefaultAddress,
-		username:   DefaultUsername,
+		httpClient:        newHTTPClient(),
+		address:           DefaultAddress,
+		username:          DefaultUsername,
+		batchWorkers:      DefaultBatchWorkers,
+		eventBufferSize:   DefaultEventBufferSize,
+		eventReconnect:    DefaultEventReconnectPolicy,
+		keepAliveInterval: DefaultKeepAliveInterval,
+		keepAliveProbe:    DefaultKeepAliveProbe,
 	}
 
 	for _, f := range opts {
 		f(co)
 	}
 
+	if co.tlsConfig != nil {
+		co.httpClient = withTLSConfig(co.httpClient, co.tlsConfig)
+	}
+
+	co.shared = co.sharedHTTPClient != nil && co.httpClient == co.sharedHTTPClient
+
+	co.eventHTTPClient = withoutResponseHeaderTimeout(co.httpClient)
+
+	if co.debugWriter != nil {
+		co.httpClient = withDebugTransport(co.httpClient, co.debugWriter)
+		co.eventHTTPClient = withDebugTransport(co.eventHTTPClient, co.debugWriter)
+	}
+
+	if co.keepAliveInterval == 0 {
+		co.keepAliveInterval = DefaultKeepAliveInterval
+	}
+
+	if co.eventBufferSize < 0 {
+		co.eventBufferSize = 0
+	}
+
+	if co.batchWorkers < 1 {
+		co.batchWorkers = 1
+	}
+
 	if co.log == nil {
 		co.log = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
@@ -76,22 +237,44 @@ func newClientOpts(opts []Opt) *clientOpts {
 // Opt is a Livebox client option.
 type Opt func(c *clientOpts)
 
-// WithHTTPClient allows using a custom http client. If not used, the Go default
-// HTTP client is used.
+// WithHTTPClient allows using a custom http client. If not used, a client that
+// uses the transport returned by NewTransport is used.
 func WithHTTPClient(httpClient *http.Client) Opt {
 	return func(c *clientOpts) {
 		c.httpClient = httpClient
 	}
 }
 
+// withSharedHTTPClient uses an HTTP client shared with other clients.
+func withSharedHTTPClient(httpClient *http.Client) Opt {
+	return func(c *clientOpts) {
+		c.httpClient = httpClient
+		c.sharedHTTPClient = httpClient
+	}
+}
+
 // WithAddress allows using a custom Livebox address. If not used, the Livebox
 // address is set to http://192.168.1.1.
+//
+// The address may use the https scheme and a custom port, for instance to
+// reach the Livebox through its remote administration URL. See WithTLSConfig
+// to configure how the certificate of the Livebox is verified.
 func WithAddress(address string) Opt {
 	return func(c *clientOpts) {
 		c.address = address
 	}
 }
 
+// WithBasePath sets the path under which the API endpoints are located, when
+// the Livebox is reached through a reverse proxy or a remote access URL that
+// uses a path prefix. The path of the address set with WithAddress is also
+// used as a prefix.
+func WithBasePath(basePath string) Opt {
+	return func(c *clientOpts) {
+		c.basePath = basePath
+	}
+}
+
 // WithLogger attaches a logger to the client. Logging is disabled if unset.
 func WithLogger(log *slog.Logger) Opt {
 	return func(c *clientOpts) {
@@ -100,7 +283,8 @@ func WithLogger(log *slog.Logger) Opt {
 }
 
 // WithUsername sets the username that will be used to authenticate. Defaults
-// to "admin" if not specified.
+// to "admin" if not specified. Users that are not administrators can only send
+// some requests, the other ones fail with ErrInsufficientPermissions.
 func WithUsername(username string) Opt {
 	return func(c *clientOpts) {
 		c.username = username
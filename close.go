@@ -0,0 +1,60 @@
+package livebox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// ErrClientClosed is returned by requests sent after the client was closed.
+var ErrClientClosed = errors.New("livebox client is closed")
+
+// Timeout of the logout request sent by Close.
+const logoutTimeout = 5 * time.Second
+
+// WithLogoutOnClose ends the session on the Livebox when the client is closed,
+// so that it does not count towards the sessions of the user until it
+// expires.
+func WithLogoutOnClose() Opt {
+	return func(c *clientOpts) {
+		c.logoutOnClose = true
+	}
+}
+
+// Close stops the event listeners and the keep-alive goroutine, ends the
+// session if WithLogoutOnClose is used, and closes the idle connections. The
+// idle connections of the HTTP client shared by a Fleet are closed by
+// Fleet.Close instead.
+// Requests sent after Close fail with ErrClientClosed. It is safe to call Close
+// several times, only the first call has an effect.
+func (c *Client) Close() error {
+	if !c.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	for _, l := range c.events.all() {
+		l.setErr(ErrClientClosed)
+		l.Close()
+	}
+
+	var err error
+	if _, ok := c.client.SessionInfo(); ok && c.logoutOnClose {
+		ctx, cancel := context.WithTimeout(context.Background(), logoutTimeout)
+		defer cancel()
+
+		err = c.send(ctx, sah.SahDeviceInformation.ReleaseContext.Request(request.Parameters{
+			"applicationName": "webui",
+		}), new(any), request.WithoutAutoReauth())
+	}
+
+	if c.sharedHTTPClient {
+		c.client.CloseIdleEventConnections()
+	} else {
+		c.client.CloseIdleConnections()
+	}
+
+	return err
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+const (
+	day  = 24 * 60 * 60
+	week = 7 * day
+)
+
+// weekdays are the names of the days in the schedule periods, starting on
+// Monday.
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// accessCommand groups the parental control commands.
+func accessCommand() *command {
+	return &command{
+		name:  "access",
+		short: "pause, resume or schedule the internet access of devices (parental control)",
+		subs: []*command{
+			accessListCommand(),
+			deviceCommand("pause", "pause the internet access of a device until it is resumed", (*livebox.Devices).Block),
+			deviceCommand("resume", "resume the internet access of a paused device, its schedule still applies", (*livebox.Devices).Unblock),
+			accessScheduleCommand(),
+		},
+	}
+}
+
+// accessListCommand lists the devices whose internet access is blocked.
+func accessListCommand() *command {
+	return &command{
+		name:  "list",
+		short: "list the devices whose internet access is currently blocked",
+		read:  true,
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			blocked, err := client.Devices().Blocked(ctx)
+			if err != nil {
+				return err
+			}
+
+			return printList(app, blocked, []column[response.BlockedDevice]{
+				{header: "NAME", value: func(b response.BlockedDevice) string { return b.Device.Name }},
+				{header: "MAC", value: func(b response.BlockedDevice) string { return b.Device.PhysAddress }},
+				{header: "REASON", value: func(b response.BlockedDevice) string { return string(b.Reason) }},
+			})
+		},
+	}
+}
+
+// accessScheduleCommand shows the periods during which a device is blocked,
+// or replaces them.
+func accessScheduleCommand() *command {
+	var clearPeriods bool
+
+	return &command{
+		name:  "schedule",
+		args:  "<mac> [<days>/<HH:MM>-<HH:MM>...]",
+		short: "show or set the periods of the week during which a device is blocked, e.g. mon-fri/22:00-07:00 sat,sun/23:30-09:00",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&clearPeriods, "clear", false, "remove all the periods")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("%w: expected a MAC address", errUsage)
+			}
+
+			mac, specs := args[0], args[1:]
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			if len(specs) == 0 && !clearPeriods {
+				schedule, err := client.Devices().Schedule(ctx, mac)
+				if err != nil {
+					return err
+				}
+
+				var periods []response.SchedulePeriod
+				if schedule != nil {
+					periods = schedule.Periods
+				}
+
+				return printList(app, periods, []column[response.SchedulePeriod]{
+					{header: "FROM", value: func(p response.SchedulePeriod) string { return formatWeekTime(p.Begin) }},
+					{header: "TO", value: func(p response.SchedulePeriod) string { return formatWeekTime(p.End) }},
+					{header: "STATE", value: func(p response.SchedulePeriod) string { return p.State }},
+				})
+			}
+
+			if clearPeriods && len(specs) > 0 {
+				return fmt.Errorf("%w: -clear cannot be used with periods", errUsage)
+			}
+
+			var periods []response.SchedulePeriod
+			for _, spec := range specs {
+				p, err := parsePeriods(spec)
+				if err != nil {
+					return fmt.Errorf("%w: %w", errUsage, err)
+				}
+
+				periods = append(periods, p...)
+			}
+
+			return client.Devices().SetSchedule(ctx, mac, periods)
+		},
+	}
+}
+
+// parsePeriods parses the periods of a schedule given as
+// <days>/<HH:MM>-<HH:MM>. Days are a comma-separated list of days or ranges
+// of days (e.g. mon-fri,sun), or "daily". A period ending before it begins
+// ends the next day.
+func parsePeriods(spec string) ([]response.SchedulePeriod, error) {
+	days, hours, ok := strings.Cut(spec, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid period %q, expected <days>/<HH:MM>-<HH:MM>", spec)
+	}
+
+	from, to, ok := strings.Cut(hours, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid hours %q, expected <HH:MM>-<HH:MM>", hours)
+	}
+
+	begin, err := parseTimeOfDay(from)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := parseTimeOfDay(to)
+	if err != nil {
+		return nil, err
+	}
+
+	if end <= begin {
+		end += day
+	}
+
+	indexes, err := parseDays(days)
+	if err != nil {
+		return nil, err
+	}
+
+	var periods []response.SchedulePeriod
+	for _, i := range indexes {
+		b, e := i*day+begin, i*day+end
+
+		// The week wraps around on Sunday night.
+		if e > week {
+			periods = append(periods, response.SchedulePeriod{Begin: 0, End: e - week})
+			e = week
+		}
+
+		periods = append(periods, response.SchedulePeriod{Begin: b, End: e})
+	}
+
+	return periods, nil
+}
+
+// parseDays returns the indexes of the days of a period, Monday is 0.
+func parseDays(s string) ([]int, error) {
+	if s == "daily" {
+		return []int{0, 1, 2, 3, 4, 5, 6}, nil
+	}
+
+	var indexes []int
+	for _, part := range strings.Split(s, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		if !isRange {
+			last = first
+		}
+
+		i, err := parseDay(first)
+		if err != nil {
+			return nil, err
+		}
+
+		j, err := parseDay(last)
+		if err != nil {
+			return nil, err
+		}
+
+		// Ranges may wrap around the week, e.g. sat-mon.
+		for ; i != j; i = (i + 1) % 7 {
+			indexes = append(indexes, i)
+		}
+		indexes = append(indexes, j)
+	}
+
+	return indexes, nil
+}
+
+// parseDay returns the index of a day, Monday is 0.
+func parseDay(s string) (int, error) {
+	for i, name := range weekdays {
+		if strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown day %q, expected one of %s or daily", s, strings.Join(weekdays, ", "))
+}
+
+// parseTimeOfDay returns the number of seconds since midnight of a HH:MM
+// time, 24:00 is the end of the day.
+func parseTimeOfDay(s string) (int, error) {
+	if s == "24:00" {
+		return day, nil
+	}
+
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+
+	return t.Hour()*3600 + t.Minute()*60, nil
+}
+
+// formatWeekTime formats a number of seconds since Monday 00:00.
+func formatWeekTime(seconds int) string {
+	if seconds >= week {
+		return "sun 24:00"
+	}
+
+	s := seconds % day
+
+	return fmt.Sprintf("%s %02d:%02d", weekdays[seconds/day], s/3600, s%3600/60)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"gopkg.in/yaml.v3"
+)
+
+// batchRequest is a request of a batch file.
+type batchRequest struct {
+	// Name of the request in the report, defaults to service:method.
+	Name    string             `yaml:"name"`
+	Service string             `yaml:"service"`
+	Method  string             `yaml:"method"`
+	Params  request.Parameters `yaml:"params"`
+}
+
+// batchReport is the result of a request of a batch file.
+type batchReport struct {
+	Name     string          `json:"Name"`
+	Service  string          `json:"Service"`
+	Method   string          `json:"Method"`
+	Response json.RawMessage `json:"Response,omitempty"`
+	Error    string          `json:"Error,omitempty"`
+}
+
+// batchCommand sends the requests of a YAML file and prints a report with all
+// the responses.
+func batchCommand() *command {
+	parallel := 1
+
+	return &command{
+		name:  "batch",
+		args:  "<requests.yaml>",
+		short: "send the requests of a YAML file and print all the responses",
+		flags: func(fs *flag.FlagSet) {
+			fs.IntVar(&parallel, "parallel", 1, "maximum number of requests sent concurrently")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 1); err != nil {
+				return err
+			}
+
+			reqs, err := readBatchFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			app.opts = append(app.opts, livebox.WithBatchWorkers(parallel))
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			batch := make([]*request.Request, len(reqs))
+			for i, req := range reqs {
+				batch[i] = request.New(req.Service, req.Method, req.Params)
+			}
+
+			var (
+				reports = make([]batchReport, len(reqs))
+				failed  int
+				// Returned so that the exit code tells the class of the
+				// failures.
+				firstErr error
+			)
+
+			for i, res := range client.Batch(ctx, batch...) {
+				reports[i] = batchReport{
+					Name:     reqs[i].Name,
+					Service:  reqs[i].Service,
+					Method:   reqs[i].Method,
+					Response: res.Response,
+				}
+
+				if res.Err != nil {
+					reports[i].Error = res.Err.Error()
+
+					failed++
+					if firstErr == nil {
+						firstErr = res.Err
+					}
+				}
+			}
+
+			if err := printList(app, reports, batchColumns); err != nil {
+				return err
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d requests failed, first error: %w", failed, len(reqs), firstErr)
+			}
+
+			return nil
+		},
+	}
+}
+
+// batchColumns are the columns of the table of a batch report.
+var batchColumns = []column[batchReport]{
+	{header: "NAME", value: func(r batchReport) string { return r.Name }},
+	{header: "RESULT", value: func(r batchReport) string {
+		if r.Error != "" {
+			return "error: " + r.Error
+		}
+
+		return "ok"
+	}},
+	{header: "RESPONSE", wide: true, value: func(r batchReport) string { return string(r.Response) }},
+}
+
+// readBatchFile reads the requests of a batch file:
+//
+//	requests:
+//	  - name: wan
+//	    service: NMC
+//	    method: getWANStatus
+//	  - service: NeMo.Intf.data
+//	    method: getMIBs
+//	    params:
+//	      mibs: ppp
+func readBatchFile(path string) ([]batchRequest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	var file struct {
+		Requests []batchRequest `yaml:"requests"`
+	}
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse %s: %w", errUsage, path, err)
+	}
+
+	for i := range file.Requests {
+		req := &file.Requests[i]
+
+		if req.Service == "" || req.Method == "" {
+			return nil, fmt.Errorf("%w: request %d: service and method are required", errUsage, i+1)
+		}
+
+		if req.Name == "" {
+			req.Name = req.Service + ":" + req.Method
+		}
+	}
+
+	return file.Requests, nil
+}
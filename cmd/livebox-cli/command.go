@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// errUsage is returned when the command line is invalid. The usage of the
+// command was already printed.
+var errUsage = errors.New("invalid usage")
+
+// command is a command of the CLI. A command either runs an action, or groups
+// subcommands.
+type command struct {
+	name string
+	// Arguments of the command, displayed in its usage.
+	args string
+	// One-line description of the command.
+	short string
+	// flags registers the flags of the command, it may be nil.
+	flags func(fs *flag.FlagSet)
+	// run runs the command with the remaining arguments, it is nil if the
+	// command only groups subcommands.
+	run  func(ctx context.Context, app *app, args []string) error
+	subs []*command
+	// Whether the command only reads data, it can then be run periodically
+	// with -watch.
+	read bool
+	// Whether the command is not listed in the usage, it is used by the
+	// completion scripts.
+	hidden bool
+}
+
+// execute parses the flags of the command and runs it, or the subcommand
+// given by the first argument.
+func (c *command) execute(ctx context.Context, app *app, path string, args []string) error {
+	path = strings.TrimSpace(path + " " + c.name)
+
+	var interval time.Duration
+
+	fs := c.flagSet(path, &interval)
+	fs.SetOutput(app.stderr)
+	fs.Usage = func() { c.usage(app.stderr, path, fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+
+		return errUsage
+	}
+
+	if len(c.subs) > 0 && fs.NArg() > 0 {
+		if sub := c.sub(fs.Arg(0)); sub != nil {
+			return sub.execute(ctx, app, path, fs.Args()[1:])
+		}
+
+		if c.run == nil {
+			fmt.Fprintf(app.stderr, "unknown command %q\n", fs.Arg(0))
+			fs.Usage()
+
+			return errUsage
+		}
+	}
+
+	if c.run == nil {
+		fs.Usage()
+
+		return errUsage
+	}
+
+	if interval > 0 {
+		title := strings.Join(append([]string{path}, fs.Args()...), " ")
+
+		return watch(ctx, app, interval, title, func() error {
+			return c.run(ctx, app, fs.Args())
+		})
+	}
+
+	return c.run(ctx, app, fs.Args())
+}
+
+// flagSet returns the flags of the command, interval is set by -watch.
+func (c *command) flagSet(path string, interval *time.Duration) *flag.FlagSet {
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+
+	if c.flags != nil {
+		c.flags(fs)
+	}
+
+	if c.read {
+		fs.DurationVar(interval, "watch", 0, "run the command at this interval (e.g. 5s) until interrupted")
+	}
+
+	return fs
+}
+
+// sub returns the subcommand with the given name, or nil.
+func (c *command) sub(name string) *command {
+	for _, sub := range c.subs {
+		if sub.name == name {
+			return sub
+		}
+	}
+
+	return nil
+}
+
+// usage prints the usage of the command, its flags and its subcommands.
+func (c *command) usage(w io.Writer, path string, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: %s", path)
+	if c.flags != nil || c.read {
+		fmt.Fprint(w, " [flags]")
+	}
+	switch {
+	case len(c.subs) > 0 && c.run != nil:
+		fmt.Fprint(w, " [command]")
+	case len(c.subs) > 0:
+		fmt.Fprint(w, " <command>")
+	}
+	if c.args != "" {
+		fmt.Fprint(w, " "+c.args)
+	}
+	fmt.Fprintln(w)
+
+	if c.short != "" {
+		fmt.Fprintf(w, "\n%s\n", c.short)
+	}
+
+	if len(c.subs) > 0 {
+		fmt.Fprintln(w, "\nCommands:")
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, sub := range c.subs {
+			if sub.hidden {
+				continue
+			}
+
+			fmt.Fprintf(tw, "  %s\t%s\n", sub.name, sub.short)
+		}
+		tw.Flush()
+	}
+
+	if c.flags != nil || c.read {
+		fmt.Fprintln(w, "\nFlags:")
+		fs.PrintDefaults()
+	}
+}
+
+// exactArgs returns an error if the number of arguments is not n.
+func exactArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("%w: expected %d argument(s), got %d", errUsage, n, len(args))
+	}
+
+	return nil
+}
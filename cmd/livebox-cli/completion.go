@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// Scripts printed by the completion command. They call the hidden
+// __complete command with the words of the command line, which prints the
+// possible values of the last word as "value\tdescription" lines.
+const (
+	bashCompletion = `# bash completion for livebox-cli, load it with:
+#   source <(livebox-cli completion bash)
+_livebox_cli() {
+	local line=${COMP_LINE:0:COMP_POINT} words word
+	read -ra words <<<"$line"
+	[[ $line == *" " ]] && words+=("")
+	word=${words[${#words[@]}-1]}
+
+	local IFS=$'\n'
+	COMPREPLY=($("${words[0]}" __complete -- "${words[@]:1}" 2>/dev/null | cut -f1))
+
+	# MAC addresses contain colons, which separate words for bash.
+	if [[ $word == *:* && $COMP_WORDBREAKS == *:* ]]; then
+		COMPREPLY=("${COMPREPLY[@]#"${word%"${word##*:}"}"}")
+	fi
+}
+complete -o default -F _livebox_cli livebox-cli
+`
+
+	zshCompletion = `#compdef livebox-cli
+# zsh completion for livebox-cli, load it with:
+#   source <(livebox-cli completion zsh)
+_livebox_cli() {
+	local -a lines described
+	local line
+	lines=("${(@f)$(${words[1]} __complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+
+	for line in $lines; do
+		[[ -n $line ]] || continue
+		described+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+	done
+
+	if (( ${#described} )); then
+		_describe livebox-cli described
+	else
+		_files
+	fi
+}
+compdef _livebox_cli livebox-cli
+`
+
+	fishCompletion = `# fish completion for livebox-cli, load it with:
+#   livebox-cli completion fish | source
+function __livebox_cli_complete
+	set -l words (commandline -opc) (commandline -ct)
+	$words[1] __complete -- $words[2..-1] 2>/dev/null
+end
+complete -c livebox-cli -f -a '(__livebox_cli_complete)'
+`
+)
+
+// flagValues are the values completed for the flags that accept a fixed set
+// of values.
+var flagValues = map[string][]string{
+	"output":   {"json", "yaml", "table", "wide"},
+	"o":        {"json", "yaml", "table", "wide"},
+	"protocol": {"tcp", "udp", "both"},
+}
+
+// completionCommand prints the completion script of a shell.
+func completionCommand() *command {
+	script := func(name, script string) *command {
+		return &command{
+			name:  name,
+			short: "print the completion script of " + name,
+			run: func(ctx context.Context, app *app, args []string) error {
+				if err := exactArgs(args, 0); err != nil {
+					return err
+				}
+
+				_, err := fmt.Fprint(app.stdout, script)
+
+				return err
+			},
+		}
+	}
+
+	return &command{
+		name:  "completion",
+		short: "print a shell completion script, e.g. source <(livebox-cli completion bash)",
+		subs: []*command{
+			script("bash", bashCompletion),
+			script("zsh", zshCompletion),
+			script("fish", fishCompletion),
+		},
+	}
+}
+
+// completeCommand prints the possible values of the last word of a command
+// line, it is called by the completion scripts.
+func completeCommand() *command {
+	return &command{
+		name:   "__complete",
+		args:   "-- <words>",
+		hidden: true,
+		run: func(ctx context.Context, app *app, args []string) error {
+			if len(args) == 0 {
+				args = []string{""}
+			}
+
+			for _, c := range complete(rootCommand(app), args) {
+				fmt.Fprintf(app.stdout, "%s\t%s\n", c.value, c.description)
+			}
+
+			return nil
+		},
+	}
+}
+
+// completion is a possible value of a word.
+type completion struct {
+	value       string
+	description string
+}
+
+// complete returns the possible values of the last word, words are the
+// arguments of the root command. Subcommands, flags and their known values
+// are completed, as well as the MAC addresses of the devices found in the
+// cache written by "devices list".
+func complete(root *command, words []string) []completion {
+	var (
+		cmd        = root
+		interval   time.Duration
+		fs         = cmd.flagSet(cmd.name, &interval)
+		positional int
+		// Flag whose value is the last word.
+		valueOf string
+	)
+
+	current := words[len(words)-1]
+
+	for i := 0; i < len(words)-1; i++ {
+		word := words[i]
+
+		switch {
+		case word == "--":
+		case strings.HasPrefix(word, "-"):
+			name := strings.TrimLeft(word, "-")
+			if f := fs.Lookup(name); f != nil && !isBoolFlag(f) {
+				if i == len(words)-2 {
+					valueOf = name
+				}
+
+				i++
+			}
+		case positional == 0 && cmd.sub(word) != nil:
+			cmd = cmd.sub(word)
+			fs = cmd.flagSet(cmd.name, &interval)
+		default:
+			positional++
+		}
+	}
+
+	var candidates []completion
+
+	switch {
+	case valueOf != "":
+		for _, v := range flagValues[valueOf] {
+			candidates = append(candidates, completion{value: v})
+		}
+	case strings.HasPrefix(current, "-"):
+		fs.VisitAll(func(f *flag.Flag) {
+			candidates = append(candidates, completion{value: "-" + f.Name, description: f.Usage})
+		})
+	default:
+		if positional == 0 {
+			for _, sub := range cmd.subs {
+				if !sub.hidden {
+					candidates = append(candidates, completion{value: sub.name, description: sub.short})
+				}
+			}
+		}
+
+		if args := strings.Fields(cmd.args); positional < len(args) && args[positional] == "<mac>" {
+			candidates = append(candidates, readDevicesCache()...)
+		}
+	}
+
+	matches := candidates[:0]
+	for _, c := range candidates {
+		if strings.HasPrefix(c.value, current) {
+			matches = append(matches, c)
+		}
+	}
+
+	return matches
+}
+
+// isBoolFlag returns whether a flag does not need a value.
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// devicesCachePath returns the path of the file where the devices are cached
+// for the completion.
+func devicesCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "livebox-cli", "devices"), nil
+}
+
+// writeDevicesCache caches the MAC addresses and the names of the devices for
+// the completion. Errors are ignored, the completion is only less helpful.
+func writeDevicesCache(devices []response.Device) {
+	path, err := devicesCachePath()
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+
+	var b strings.Builder
+	for _, d := range devices {
+		fmt.Fprintf(&b, "%s\t%s\n", d.PhysAddress, d.Name)
+	}
+
+	_ = os.WriteFile(path, []byte(b.String()), 0o600)
+}
+
+// readDevicesCache returns the devices cached by writeDevicesCache, as
+// completions of their MAC address.
+func readDevicesCache() []completion {
+	path, err := devicesCachePath()
+	if err != nil {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var devices []completion
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		mac, name, _ := strings.Cut(scanner.Text(), "\t")
+		if mac != "" {
+			devices = append(devices, completion{value: mac, description: name})
+		}
+	}
+
+	return devices
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// devicesCommand groups the commands that manage the devices known by the
+// Livebox.
+func devicesCommand() *command {
+	return &command{
+		name:  "devices",
+		short: "manage the devices known by the Livebox",
+		subs: []*command{
+			devicesListCommand(),
+			deviceCommand("block", "pause the internet access of a device", (*livebox.Devices).Block),
+			deviceCommand("unblock", "resume the internet access of a device paused with block", (*livebox.Devices).Unblock),
+			deviceCommand("wake", "send a Wake-on-LAN packet to a device", (*livebox.Devices).WakeOnLAN),
+			{
+				name:  "rename",
+				args:  "<mac> <name>",
+				short: "set the name of a device",
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 2); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					return client.Devices().SetName(ctx, args[0], args[1])
+				},
+			},
+		},
+	}
+}
+
+// devicesListCommand lists the devices, optionally filtered.
+func devicesListCommand() *command {
+	var (
+		active bool
+		intf   string
+		search string
+	)
+
+	return &command{
+		name:  "list",
+		short: "list the devices",
+		read:  true,
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&active, "active", false, "only list the connected devices")
+			fs.StringVar(&intf, "interface", "", "only list the devices connected to this interface (e.g. ETH1, wl0)")
+			fs.StringVar(&search, "search", "", "only list the devices whose name or MAC address contains this text")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			devices, err := client.Devices().List(ctx)
+			if err != nil {
+				return err
+			}
+
+			writeDevicesCache(devices)
+
+			search = strings.ToLower(search)
+
+			filtered := make([]response.Device, 0, len(devices))
+			for _, d := range devices {
+				switch {
+				case active && !d.Active:
+				case intf != "" && !strings.EqualFold(d.Layer2Interface, intf):
+				case search != "" &&
+					!strings.Contains(strings.ToLower(d.Name), search) &&
+					!strings.Contains(strings.ToLower(d.PhysAddress), search):
+				default:
+					filtered = append(filtered, d)
+				}
+			}
+
+			return printList(app, filtered, deviceColumns)
+		},
+	}
+}
+
+// deviceCommand returns a command that runs an action on the device with the
+// MAC address given as argument.
+func deviceCommand(name, short string, action func(d *livebox.Devices, ctx context.Context, mac string) error) *command {
+	return &command{
+		name:  name,
+		args:  "<mac>",
+		short: short,
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 1); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			return action(client.Devices(), ctx, args[0])
+		},
+	}
+}
+
+// deviceColumns are the columns of the table of devices.
+var deviceColumns = []column[response.Device]{
+	{header: "NAME", value: func(d response.Device) string { return d.Name }},
+	{header: "MAC", value: func(d response.Device) string { return d.PhysAddress }},
+	{header: "IP", value: func(d response.Device) string { return d.IPAddress }},
+	{header: "INTERFACE", value: func(d response.Device) string { return d.Layer2Interface }},
+	{header: "ACTIVE", value: func(d response.Device) string { return strconv.FormatBool(d.Active) }},
+	{header: "TYPE", wide: true, value: func(d response.Device) string { return d.DeviceType }},
+	{header: "LAST CONNECTION", wide: true, value: func(d response.Device) string { return formatTime(d.LastConnection) }},
+	{header: "TAGS", wide: true, value: func(d response.Device) string { return d.Tags }},
+}
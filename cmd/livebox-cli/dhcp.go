@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// dhcpCommand groups the commands that manage the DHCP server.
+func dhcpCommand() *command {
+	return &command{
+		name:  "dhcp",
+		short: "manage the DHCP server",
+		subs: []*command{
+			dhcpLeasesCommand(),
+			{
+				name:  "static-add",
+				args:  "<mac> <ip>",
+				short: "always give the same IP address to a device",
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 2); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					return client.AddStaticLease(ctx, args[0], args[1])
+				},
+			},
+			{
+				name:  "static-rm",
+				args:  "<mac>",
+				short: "remove the static lease of a device",
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 1); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					return client.DeleteStaticLease(ctx, args[0])
+				},
+			},
+			dhcpConfigCommand(),
+		},
+	}
+}
+
+// dhcpLeasesCommand lists the leases of the DHCP server.
+func dhcpLeasesCommand() *command {
+	var active bool
+
+	return &command{
+		name:  "leases",
+		short: "list the leases",
+		read:  true,
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&active, "active", false, "only list the leases in use")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			leases, err := client.GetDHCPLeases(ctx)
+			if err != nil {
+				return err
+			}
+
+			filtered := make([]response.DHCPLease, 0, len(leases))
+			for _, lease := range leases {
+				if !active || lease.Active {
+					filtered = append(filtered, lease)
+				}
+			}
+
+			return printList(app, filtered, leaseColumns)
+		},
+	}
+}
+
+// leaseColumns are the columns of the table of leases.
+var leaseColumns = []column[response.DHCPLease]{
+	{header: "NAME", value: func(l response.DHCPLease) string { return l.FriendlyName }},
+	{header: "MAC", value: func(l response.DHCPLease) string { return l.MACAddress }},
+	{header: "IP", value: func(l response.DHCPLease) string { return l.IPAddress }},
+	{header: "ACTIVE", value: func(l response.DHCPLease) string { return strconv.FormatBool(l.Active) }},
+	{header: "STATIC", value: func(l response.DHCPLease) string { return strconv.FormatBool(l.Reserved) }},
+	{header: "REMAINING", wide: true, value: func(l response.DHCPLease) string {
+		if l.LeaseTimeRemaining <= 0 {
+			return ""
+		}
+
+		return (time.Duration(l.LeaseTimeRemaining) * time.Second).String()
+	}},
+}
+
+// dhcpConfigCommand shows the configuration of the DHCP server, or changes it
+// when flags are given.
+func dhcpConfigCommand() *command {
+	var (
+		enable           *bool
+		poolMin, poolMax string
+	)
+
+	return &command{
+		name:  "config",
+		short: "show the configuration of the DHCP server, or change it with flags",
+		flags: func(fs *flag.FlagSet) {
+			fs.Func("enable", "enable (true) or disable (false) the DHCP server", func(s string) error {
+				b, err := strconv.ParseBool(s)
+				enable = &b
+				return err
+			})
+			fs.StringVar(&poolMin, "min", "", "first address of the DHCP pool")
+			fs.StringVar(&poolMax, "max", "", "last address of the DHCP pool")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			cfg, err := client.GetLANConfig(ctx)
+			if err != nil {
+				return err
+			}
+
+			if enable == nil && poolMin == "" && poolMax == "" {
+				return app.print(cfg)
+			}
+
+			if enable != nil {
+				cfg.DHCPEnable = *enable
+			}
+			if poolMin != "" {
+				cfg.DHCPMinAddress = poolMin
+			}
+			if poolMax != "" {
+				cfg.DHCPMaxAddress = poolMax
+			}
+
+			return client.SetLANConfig(ctx, cfg)
+		},
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// diagSection is a file of a diagnostic bundle, filled with the result of
+// fetch.
+type diagSection struct {
+	file  string
+	fetch func(ctx context.Context, client *livebox.Client) (any, error)
+}
+
+// diagSections are the files of a diagnostic bundle, besides the events and
+// the manifest.
+var diagSections = []diagSection{
+	{"device-info.json", func(ctx context.Context, c *livebox.Client) (any, error) { return c.GetDeviceInfo(ctx) }},
+	{"reboot-history.json", func(ctx context.Context, c *livebox.Client) (any, error) { return c.GetRebootHistory(ctx) }},
+	{"wan-status.json", func(ctx context.Context, c *livebox.Client) (any, error) { return c.GetWANStatus(ctx) }},
+	{"wan-mode.json", func(ctx context.Context, c *livebox.Client) (any, error) { return c.GetWANMode(ctx) }},
+	{"dsl-stats.json", func(ctx context.Context, c *livebox.Client) (any, error) {
+		// Only available on DSL lines, there is no typed method yet.
+		out := json.RawMessage{}
+		err := c.Request(ctx, request.New("NeMo.Intf.dsl0", "getDSLStats", nil), &out)
+		return out, err
+	}},
+	{"interface-counters.json", func(ctx context.Context, c *livebox.Client) (any, error) {
+		counters, err := c.GetInterfaceCounters(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// The name of the interface is not part of the JSON encoding of
+		// the counters.
+		byInterface := make(map[string]response.InterfaceCounters, len(counters))
+		for _, c := range counters {
+			byInterface[c.Interface] = c
+		}
+
+		return byInterface, nil
+	}},
+	{"topology.json", func(ctx context.Context, c *livebox.Client) (any, error) { return c.GetTopology(ctx) }},
+}
+
+// diagManifest describes the content of a diagnostic bundle.
+type diagManifest struct {
+	Created time.Time `json:"Created"`
+	Files   []string  `json:"Files"`
+	// Errors of the sections that could not be collected, by file.
+	Errors map[string]string `json:"Errors,omitempty"`
+}
+
+// diagCommand groups the commands that help diagnose issues.
+func diagCommand() *command {
+	return &command{
+		name:  "diag",
+		short: "collect diagnostic information",
+		subs: []*command{
+			diagCollectCommand(),
+		},
+	}
+}
+
+// diagCollectCommand writes a diagnostic bundle, a gzipped tar archive of
+// the state of the Livebox and of the events received for a while, without
+// personal data.
+func diagCollectCommand() *command {
+	var (
+		out    string
+		events time.Duration
+	)
+
+	return &command{
+		name:  "collect",
+		short: "write the state and the recent events of the Livebox to an archive without personal data, to share in a bug report",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&out, "out", "", "path of the archive (default livebox-diag-<time>.tar.gz)")
+			fs.DurationVar(&events, "events", 10*time.Second, "time spent recording events, 0 to skip them")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			if out == "" {
+				out = "livebox-diag-" + time.Now().Format("20060102-150405") + ".tar.gz"
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			files, err := collectDiag(ctx, app, client, events)
+			if err != nil {
+				return err
+			}
+
+			if err := writeTarGz(out, files); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(app.stderr, "Diagnostic bundle written to %s, check its content before sharing it.\n", out)
+
+			return nil
+		},
+	}
+}
+
+// diagFile is a file of a diagnostic bundle.
+type diagFile struct {
+	name string
+	data []byte
+}
+
+// collectDiag collects the files of a diagnostic bundle. The sections that
+// fail are listed in the manifest, they do not stop the collection.
+func collectDiag(ctx context.Context, app *app, client *livebox.Client, events time.Duration) ([]diagFile, error) {
+	var (
+		s        = newSanitizer()
+		files    []diagFile
+		manifest = diagManifest{Created: time.Now(), Errors: map[string]string{}}
+	)
+
+	// Events are recorded while the sections are collected.
+	deadline := time.Now().Add(events)
+	recordCtx, stopRecording := context.WithCancel(ctx)
+	defer stopRecording()
+
+	var (
+		recorded   bytes.Buffer
+		recordDone = make(chan error, 1)
+	)
+	if events > 0 {
+		l := client.Events(recordCtx, nil)
+
+		go func() {
+			defer l.Close()
+			recordDone <- livebox.WriteEvents(&recorded, l.C)
+		}()
+	} else {
+		recordDone <- nil
+	}
+
+	for _, section := range diagSections {
+		v, err := section.fetch(ctx, client)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if err != nil {
+			fmt.Fprintf(app.stderr, "Failed to collect %s: %s\n", section.file, err)
+			manifest.Errors[section.file] = s.sanitizeError(err)
+
+			continue
+		}
+
+		data, err := s.sanitizeJSON(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sanitize %s: %w", section.file, err)
+		}
+
+		files = append(files, diagFile{name: section.file, data: data})
+	}
+
+	if events > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Until(deadline)):
+		}
+
+		stopRecording()
+		if err := <-recordDone; err != nil {
+			return nil, fmt.Errorf("failed to record events: %w", err)
+		}
+
+		data, err := s.sanitizeLines(recorded.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to sanitize events: %w", err)
+		}
+
+		files = append(files, diagFile{name: "events.ndjson", data: data})
+	}
+
+	for _, f := range files {
+		manifest.Files = append(manifest.Files, f.name)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]diagFile{{name: "manifest.json", data: data}}, files...), nil
+}
+
+// sanitizeJSON returns the sanitized and indented JSON encoding of v.
+func (s *sanitizer) sanitizeJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(s.sanitize(doc), "", "  ")
+}
+
+// sanitizeLines sanitizes newline-delimited JSON.
+func (s *sanitizer) sanitizeLines(b []byte) ([]byte, error) {
+	var out bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		dec := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		dec.UseNumber()
+
+		var doc any
+		if err := dec.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		line, err := json.Marshal(s.sanitize(doc))
+		if err != nil {
+			return nil, err
+		}
+
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes(), scanner.Err()
+}
+
+// writeTarGz writes files to a gzipped tar archive. The archive is removed if
+// it cannot be written entirely.
+func writeTarGz(path string, files []diagFile) (err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(path)
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	for _, file := range files {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    file.name,
+			Mode:    0o644,
+			Size:    int64(len(file.data)),
+			ModTime: now,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+// discoverCommand lists the Liveboxes and Wi-Fi extenders found on the LAN,
+// their address can then be given to -address.
+func discoverCommand() *command {
+	return &command{
+		name:  "discover",
+		short: "find the Liveboxes on the local network, use their address with -address",
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			devices, err := livebox.DiscoverAll(ctx)
+			if err != nil {
+				return err
+			}
+
+			return printList(app, devices, discoveredColumns)
+		},
+	}
+}
+
+// discoveredColumns are the columns of the table of discovered devices.
+var discoveredColumns = []column[livebox.DiscoveredDevice]{
+	{header: "ADDRESS", value: func(d livebox.DiscoveredDevice) string { return d.Address }},
+	{header: "MODEL", value: func(d livebox.DiscoveredDevice) string { return d.Model }},
+	{header: "FIRMWARE", value: func(d livebox.DiscoveredDevice) string { return d.Firmware }},
+	{header: "SOURCE", wide: true, value: func(d livebox.DiscoveredDevice) string { return d.Source }},
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+// eventsCommand writes the events of the Livebox to stdout as
+// newline-delimited JSON, until it is interrupted.
+func eventsCommand() *command {
+	return &command{
+		name:  "events",
+		args:  "[event...]",
+		short: "watch events as newline-delimited JSON, all of them if none is given",
+		run: func(ctx context.Context, app *app, args []string) error {
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			l := client.Events(ctx, args)
+			defer l.Close()
+
+			if err := livebox.WriteEvents(app.stdout, l.C); err != nil {
+				return err
+			}
+
+			// The listener stops when the command is interrupted.
+			if err := l.Err(); err != nil && !errors.Is(err, context.Canceled) {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
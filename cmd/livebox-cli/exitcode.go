@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// Exit codes of the CLI, so that scripts can tell the failures apart.
+const (
+	exitOK = 0
+	// Any other error.
+	exitError = 1
+	// Invalid command line.
+	exitUsage = 2
+	// The login failed, or no password is available.
+	exitAuth = 3
+	// The user is not allowed to send the request.
+	exitPermission = 4
+	// The Livebox could not be reached, or it did not respond in time, or
+	// none was found.
+	exitUnreachable = 5
+	// The Livebox returned an error.
+	exitAPI = 6
+)
+
+// exitCode returns the exit code for an error returned by a command.
+func exitCode(err error) int {
+	var (
+		urlErr *url.Error
+		apiErr *response.Error
+	)
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case errors.Is(err, livebox.ErrInsufficientPermissions):
+		return exitPermission
+	case errors.Is(err, livebox.ErrInvalidCredentials),
+		errors.Is(err, livebox.ErrTooManyLoginAttempts),
+		errors.Is(err, livebox.ErrUnsupportedAuthentication),
+		errors.Is(err, errNoPassword):
+		return exitAuth
+	case errors.As(err, &urlErr),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, livebox.ErrLiveboxNotFound):
+		return exitUnreachable
+	case errors.As(err, &apiErr), errors.Is(err, livebox.ErrUnsuccessful):
+		return exitAPI
+	default:
+		return exitError
+	}
+}
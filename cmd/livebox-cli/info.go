@@ -0,0 +1,29 @@
+package main
+
+import "context"
+
+// infoCommand prints general information about the Livebox.
+func infoCommand() *command {
+	return &command{
+		name:  "info",
+		short: "show general information about the Livebox",
+		read:  true,
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			info, err := client.GetDeviceInfo(ctx)
+			if err != nil {
+				return err
+			}
+
+			return app.print(info)
+		},
+	}
+}
@@ -2,62 +2,128 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
-	"log"
+	"io"
 	"os"
+	"os/signal"
 
 	"github.com/Tomy2e/livebox-api-client"
-	"github.com/Tomy2e/livebox-api-client/api/request"
 )
 
 func main() {
-	var (
-		service = flag.String("service", "", "service")
-		method  = flag.String("method", "", "method")
-		params  = flag.String("params", "", "JSON-encoded params")
-	)
-	flag.Parse()
-
-	client, err := livebox.NewClient(os.Getenv("ADMIN_PASSWORD"))
-	if err != nil {
-		log.Fatalf("failed to create livebox client: %s", err)
-	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	req, err := newRequest(*service, *method, *params)
-	if err != nil {
-		log.Fatalf("failed to create request: %s", err)
+	app := &app{output: outputJSON, stdout: os.Stdout, stderr: os.Stderr}
+	app.credentials = &credentials{app: app}
+	defer app.close()
+
+	err := rootCommand(app).execute(ctx, app, "", os.Args[1:])
+	if err == nil {
+		return
 	}
 
-	out := json.RawMessage{}
-	if err := client.Request(context.Background(), req, &out); err != nil {
-		log.Fatalf("request failed: %s", err)
+	// The usage was already printed if the error is errUsage itself.
+	if err != errUsage {
+		fmt.Fprintf(os.Stderr, "livebox-cli: %s\n", err)
 	}
 
-	fmt.Println(string(out))
+	code := exitCode(err)
+
+	app.close()
+	os.Exit(code)
 }
 
-func newRequest(service, method, params string) (*request.Request, error) {
-	if service == "" {
-		return nil, errors.New("-service is missing")
+// rootCommand returns the root command of the CLI, whose flags configure the
+// client used by the subcommands.
+func rootCommand(app *app) *command {
+	return &command{
+		name:  "livebox-cli",
+		short: "livebox-cli sends requests to the API of a Livebox. The password is read from\nthe ADMIN_PASSWORD environment variable, or from the OS keyring after running\nlivebox-cli login, or it is prompted.\n\nExit codes: 1 error, 2 invalid usage, 3 authentication failure, 4 permission\ndenied, 5 Livebox unreachable, 6 error returned by the Livebox.",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&app.address, "address", livebox.DefaultAddress, "address of the Livebox")
+			fs.StringVar(&app.username, "username", livebox.DefaultUsername, "user to authenticate as")
+			fs.Var(&app.output, "output", "output format: json, yaml, table or wide")
+			fs.Var(&app.output, "o", "shorthand for -output")
+			fs.Func("query", "only print the values at this path of the output (e.g. .Data.IPAddress, .[].Name)", func(s string) error {
+				q, err := parseQuery(s)
+				app.query = q
+				return err
+			})
+		},
+		subs: []*command{
+			discoverCommand(),
+			loginCommand(),
+			logoutCommand(),
+			rawCommand(),
+			batchCommand(),
+			shellCommand(),
+			infoCommand(),
+			devicesCommand(),
+			accessCommand(),
+			wifiCommand(),
+			natCommand(),
+			dhcpCommand(),
+			topologyCommand(),
+			topCommand(),
+			wanCommand(),
+			eventsCommand(),
+			diagCommand(),
+			serveCommand(),
+			rebootCommand(),
+			firmwareCommand(),
+			completionCommand(),
+			completeCommand(),
+		},
 	}
+}
+
+// app contains the global options of the CLI and the client used by the
+// commands.
+type app struct {
+	address  string
+	username string
+	output   outputFormat
+	// nil if the whole output is printed.
+	query *query
+
+	stdout io.Writer
+	stderr io.Writer
+
+	credentials *credentials
 
-	if method == "" {
-		return nil, errors.New("-method is missing")
+	// Additional options of the client, set by commands before it is
+	// created.
+	opts []livebox.Opt
+	// Created on first use by commands that send requests.
+	lc *livebox.Client
+}
+
+// client returns the Livebox client, it is created on first use.
+func (a *app) client() (*livebox.Client, error) {
+	if a.lc != nil {
+		return a.lc, nil
 	}
 
-	var parameters request.Parameters
-	if params != "" {
-		if err := json.Unmarshal([]byte(params), &parameters); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal params: %w", err)
-		}
+	opts := append([]livebox.Opt{
+		livebox.WithAddress(a.address),
+		livebox.WithCredentialsProvider(a.credentials),
+	}, a.opts...)
+
+	lc, err := livebox.NewClient("", opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create livebox client: %w", err)
 	}
 
-	return &request.Request{
-		Service:    service,
-		Method:     method,
-		Parameters: parameters,
-	}, nil
+	a.lc = lc
+
+	return lc, nil
+}
+
+// close closes the client, if it was created.
+func (a *app) close() {
+	if a.lc != nil {
+		a.lc.Close()
+	}
 }
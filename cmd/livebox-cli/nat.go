@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"gopkg.in/yaml.v3"
+)
+
+// Prefix of the ID of the port forwarding rules created by the user.
+const natIDPrefix = "webui_"
+
+// natCommand groups the commands that manage the port forwarding rules. With
+// -apply, the rules of the Livebox are reconciled with a YAML file.
+func natCommand() *command {
+	var apply string
+	var dryRun bool
+
+	return &command{
+		name:  "nat",
+		short: "manage the port forwarding rules, or apply the rules of a YAML file with -apply",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&apply, "apply", "", "create the missing rules of this YAML file and delete the extra ones")
+			fs.BoolVar(&dryRun, "dry-run", false, "with -apply, print the changes without applying them")
+		},
+		subs: []*command{
+			natListCommand(),
+			natAddCommand(),
+			{
+				name:  "delete",
+				args:  "<name>",
+				short: "delete a port forwarding rule",
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 1); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					return client.DeletePortForwarding(ctx, natID(args[0]))
+				},
+			},
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if apply == "" {
+				return fmt.Errorf("%w: a command or -apply is required", errUsage)
+			}
+
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			return natApply(ctx, app, apply, dryRun)
+		},
+	}
+}
+
+// natListCommand lists the port forwarding rules.
+func natListCommand() *command {
+	return &command{
+		name:  "list",
+		short: "list the port forwarding rules",
+		read:  true,
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			rules, err := client.GetPortForwardings(ctx)
+			if err != nil {
+				return err
+			}
+
+			return printList(app, rules, natColumns)
+		},
+	}
+}
+
+// natColumns are the columns of the table of port forwarding rules.
+var natColumns = []column[response.PortForwardingRule]{
+	{header: "NAME", value: func(r response.PortForwardingRule) string { return strings.TrimPrefix(r.ID, natIDPrefix) }},
+	{header: "PROTOCOL", value: func(r response.PortForwardingRule) string { return formatProtocol(r.Protocol) }},
+	{header: "EXTERNAL", value: func(r response.PortForwardingRule) string { return r.ExternalPort }},
+	{header: "INTERNAL", value: func(r response.PortForwardingRule) string { return r.InternalPort }},
+	{header: "DESTINATION", value: func(r response.PortForwardingRule) string { return r.DestinationIPAddress }},
+	{header: "ENABLED", value: func(r response.PortForwardingRule) string { return strconv.FormatBool(r.Enable) }},
+	{header: "SOURCE", wide: true, value: func(r response.PortForwardingRule) string { return r.SourcePrefix }},
+	{header: "STATUS", wide: true, value: func(r response.PortForwardingRule) string { return r.Status }},
+}
+
+// natAddCommand creates or updates a port forwarding rule.
+func natAddCommand() *command {
+	var spec natRule
+
+	return &command{
+		name:  "add",
+		args:  "<name>",
+		short: "create or update a port forwarding rule",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&spec.Protocol, "protocol", "tcp", "protocol: tcp, udp or both")
+			fs.StringVar(&spec.ExternalPort, "external", "", "port or range of ports on the WAN side (e.g. 8000-8010)")
+			fs.StringVar(&spec.InternalPort, "internal", "", "port on the LAN side, defaults to the external port")
+			fs.StringVar(&spec.Destination, "destination", "", "LAN address to forward the traffic to")
+			fs.StringVar(&spec.Source, "source", "", "only forward the traffic from this prefix")
+			fs.BoolFunc("disabled", "create the rule disabled", func(string) error {
+				enabled := false
+				spec.Enabled = &enabled
+				return nil
+			})
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 1); err != nil {
+				return err
+			}
+
+			spec.Name = args[0]
+
+			rule, err := spec.rule()
+			if err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			_, err = client.SetPortForwarding(ctx, rule)
+
+			return err
+		},
+	}
+}
+
+// natRule is a port forwarding rule of a YAML file applied with -apply.
+type natRule struct {
+	Name         string `yaml:"name"`
+	Protocol     string `yaml:"protocol"`
+	ExternalPort string `yaml:"externalPort"`
+	InternalPort string `yaml:"internalPort"`
+	Destination  string `yaml:"destination"`
+	Source       string `yaml:"source"`
+	// Defaults to true.
+	Enabled *bool `yaml:"enabled"`
+}
+
+// rule validates the rule and returns it as expected by the client.
+func (r *natRule) rule() (*response.PortForwardingRule, error) {
+	if r.Name == "" || r.ExternalPort == "" || r.Destination == "" {
+		return nil, fmt.Errorf("%w: rule %q: name, external port and destination are required", errUsage, r.Name)
+	}
+
+	protocol, err := parseProtocol(r.Protocol)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rule %q: %w", errUsage, r.Name, err)
+	}
+
+	internal := r.InternalPort
+	if internal == "" {
+		internal = r.ExternalPort
+	}
+
+	return &response.PortForwardingRule{
+		ID:                   natID(r.Name),
+		Description:          r.Name,
+		Enable:               r.Enabled == nil || *r.Enabled,
+		Protocol:             protocol,
+		ExternalPort:         r.ExternalPort,
+		InternalPort:         internal,
+		DestinationIPAddress: r.Destination,
+		SourcePrefix:         r.Source,
+	}, nil
+}
+
+// natChange is a change made by -apply.
+type natChange struct {
+	Action string `json:"Action"`
+	Name   string `json:"Name"`
+}
+
+// natApply reconciles the port forwarding rules of the Livebox with the rules
+// of a YAML file: missing or different rules are set, extra rules are deleted.
+func natApply(ctx context.Context, app *app, path string, dryRun bool) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file struct {
+		Rules []natRule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return fmt.Errorf("%w: failed to parse %s: %w", errUsage, path, err)
+	}
+
+	desired := make(map[string]*response.PortForwardingRule, len(file.Rules))
+	for _, spec := range file.Rules {
+		rule, err := spec.rule()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := desired[rule.ID]; ok {
+			return fmt.Errorf("%w: rule %q is defined twice", errUsage, spec.Name)
+		}
+		desired[rule.ID] = rule
+	}
+
+	client, err := app.client()
+	if err != nil {
+		return err
+	}
+
+	current, err := client.GetPortForwardings(ctx)
+	if err != nil {
+		return err
+	}
+
+	var changes []natChange
+	existing := make(map[string]bool, len(current))
+
+	for _, rule := range current {
+		existing[rule.ID] = true
+
+		want, ok := desired[rule.ID]
+		switch {
+		case !ok:
+			changes = append(changes, natChange{Action: "delete", Name: rule.ID})
+		case !sameRule(&rule, want):
+			changes = append(changes, natChange{Action: "update", Name: rule.ID})
+		}
+	}
+
+	for _, spec := range file.Rules {
+		if id := natID(spec.Name); !existing[id] {
+			changes = append(changes, natChange{Action: "create", Name: id})
+		}
+	}
+
+	if !dryRun {
+		for _, change := range changes {
+			if err := applyNATChange(ctx, client, change, desired[change.Name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return printList(app, changes, []column[natChange]{
+		{header: "ACTION", value: func(c natChange) string { return c.Action }},
+		{header: "NAME", value: func(c natChange) string { return strings.TrimPrefix(c.Name, natIDPrefix) }},
+	})
+}
+
+// applyNATChange applies a change computed by natApply.
+func applyNATChange(ctx context.Context, client *livebox.Client, change natChange, rule *response.PortForwardingRule) error {
+	if change.Action == "delete" {
+		return client.DeletePortForwarding(ctx, change.Name)
+	}
+
+	_, err := client.SetPortForwarding(ctx, rule)
+
+	return err
+}
+
+// sameRule returns whether a rule of the Livebox matches a desired rule.
+func sameRule(current, want *response.PortForwardingRule) bool {
+	return current.Enable == want.Enable &&
+		current.Protocol == want.Protocol &&
+		current.ExternalPort == want.ExternalPort &&
+		current.InternalPort == want.InternalPort &&
+		current.DestinationIPAddress == want.DestinationIPAddress &&
+		current.SourcePrefix == want.SourcePrefix
+}
+
+// natID returns the ID of a rule from its name, the prefix is optional.
+func natID(name string) string {
+	if strings.HasPrefix(name, natIDPrefix) {
+		return name
+	}
+
+	return natIDPrefix + name
+}
+
+// parseProtocol parses the protocol of a rule. An empty protocol is TCP.
+func parseProtocol(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "", "tcp", response.ProtocolTCP:
+		return response.ProtocolTCP, nil
+	case "udp", response.ProtocolUDP:
+		return response.ProtocolUDP, nil
+	case "both", "tcp/udp", response.ProtocolTCPUDP:
+		return response.ProtocolTCPUDP, nil
+	default:
+		return "", fmt.Errorf("unknown protocol %q", s)
+	}
+}
+
+// formatProtocol returns the name of the protocol of a rule.
+func formatProtocol(protocol string) string {
+	switch protocol {
+	case response.ProtocolTCP:
+		return "tcp"
+	case response.ProtocolUDP:
+		return "udp"
+	case response.ProtocolTCPUDP:
+		return "both"
+	default:
+		return protocol
+	}
+}
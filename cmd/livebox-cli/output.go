@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormat is the format used to print the results of the commands.
+type outputFormat string
+
+const (
+	outputJSON  outputFormat = "json"
+	outputYAML  outputFormat = "yaml"
+	outputTable outputFormat = "table"
+	// Like outputTable, with additional columns.
+	outputWide outputFormat = "wide"
+)
+
+var outputFormats = []outputFormat{outputJSON, outputYAML, outputTable, outputWide}
+
+// String implements flag.Value.
+func (f *outputFormat) String() string {
+	return string(*f)
+}
+
+// Set implements flag.Value.
+func (f *outputFormat) Set(s string) error {
+	for _, format := range outputFormats {
+		if outputFormat(s) == format {
+			*f = format
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown output format %q", s)
+}
+
+// column is a column of the table used to print a list of T.
+type column[T any] struct {
+	header string
+	// Only displayed with the wide output.
+	wide  bool
+	value func(T) string
+}
+
+// printList prints a list of rows. The table and wide outputs display the
+// given columns, the other outputs print the rows as is.
+func printList[T any](app *app, rows []T, columns []column[T]) error {
+	if app.query != nil || (app.output != outputTable && app.output != outputWide) {
+		return app.print(rows)
+	}
+
+	tw := tabwriter.NewWriter(app.stdout, 0, 0, 3, ' ', 0)
+
+	var headers []string
+	for _, c := range columns {
+		if !c.wide || app.output == outputWide {
+			headers = append(headers, c.header)
+		}
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		var values []string
+		for _, c := range columns {
+			if !c.wide || app.output == outputWide {
+				values = append(values, c.value(row))
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// print prints v in the selected output format. The table and wide outputs
+// display the fields of objects as rows, see printList to print lists.
+//
+// If a query is set, only the selected values are printed. Scalars are printed
+// as text, one per line.
+func (a *app) print(v any) error {
+	if a.query != nil {
+		values, err := a.query.apply(v)
+		if err != nil {
+			return err
+		}
+
+		result, lines := a.query.result(values)
+		if lines {
+			for _, value := range values {
+				text, _ := scalarText(value)
+				fmt.Fprintln(a.stdout, text)
+			}
+
+			return nil
+		}
+
+		if text, ok := scalarText(result); ok {
+			_, err := fmt.Fprintln(a.stdout, text)
+			return err
+		}
+
+		v = result
+	}
+
+	switch a.output {
+	case outputYAML:
+		return writeYAML(a.stdout, v)
+	case outputTable, outputWide:
+		return writeFields(a.stdout, v)
+	default:
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	}
+}
+
+// jsonNode returns the YAML node of the JSON encoding of v. JSON is valid
+// YAML, the node keeps the names of the JSON fields and their order.
+func jsonNode(v any) (*yaml.Node, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+
+	return doc.Content[0], nil
+}
+
+// writeYAML writes v as YAML, with the names of its JSON fields.
+func writeYAML(w io.Writer, v any) error {
+	node, err := jsonNode(v)
+	if err != nil {
+		return err
+	}
+
+	resetStyle(node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// resetStyle removes the JSON style of a node and its children, so that it is
+// written in block style.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// writeFields writes the fields of an object as a two-column table. Nested
+// values are written as compact JSON, and values that are not objects are
+// written as JSON.
+func writeFields(w io.Writer, v any) error {
+	node, err := jsonNode(v)
+	if err != nil {
+		return err
+	}
+
+	if node.Kind != yaml.MappingNode {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(w, string(b))
+
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		text := value.Value
+		if value.Kind != yaml.ScalarNode {
+			var decoded any
+			if err := value.Decode(&decoded); err != nil {
+				return err
+			}
+
+			b, err := json.Marshal(decoded)
+			if err != nil {
+				return err
+			}
+			text = string(b)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\n", key.Value, text)
+	}
+
+	return tw.Flush()
+}
+
+// formatTime formats a time in a table, the zero time is left empty.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Local().Format(time.DateTime)
+}
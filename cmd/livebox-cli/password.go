@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/term"
+)
+
+// Name of the keyring service under which the passwords are stored.
+const keyringService = "livebox-cli"
+
+// errNoPassword is returned when no password is available and it cannot be
+// prompted.
+var errNoPassword = errors.New("no password: set ADMIN_PASSWORD, run livebox-cli login, or run from a terminal")
+
+// credentials provides the password of the CLI user. The password is looked up
+// once, in order: the ADMIN_PASSWORD environment variable, the OS keyring, and
+// an interactive prompt.
+type credentials struct {
+	app *app
+
+	mu       sync.Mutex
+	password string
+	resolved bool
+}
+
+// Credentials implements livebox.CredentialsProvider.
+func (c *credentials) Credentials(ctx context.Context) (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.resolved {
+		password, err := c.resolve()
+		if err != nil {
+			return "", "", err
+		}
+
+		c.password = password
+		c.resolved = true
+	}
+
+	return c.app.username, c.password, nil
+}
+
+// set sets the password, it is not looked up anymore.
+func (c *credentials) set(password string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.password = password
+	c.resolved = true
+}
+
+func (c *credentials) resolve() (string, error) {
+	if password, ok := os.LookupEnv("ADMIN_PASSWORD"); ok {
+		return password, nil
+	}
+
+	// The keyring may be unavailable, for instance on a headless server, the
+	// password is then prompted.
+	if password, err := keyring.Get(keyringService, c.app.keyringUser()); err == nil {
+		return password, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errNoPassword
+	}
+
+	return promptPassword(c.app)
+}
+
+// keyringUser returns the name under which the password is stored in the
+// keyring, the same user may have different passwords on several Livebox.
+func (a *app) keyringUser() string {
+	return a.username + "@" + a.address
+}
+
+// promptPassword reads the password of the user.
+func promptPassword(app *app) (string, error) {
+	return promptSecret(app, "Password for "+app.keyringUser())
+}
+
+// promptSecret reads a secret from the terminal without echoing it, or reads a
+// line from stdin if it is not a terminal.
+func promptSecret(app *app, prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read secret: %w", err)
+		}
+
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprintf(app.stderr, "%s: ", prompt)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(app.stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// loginCommand checks a password and stores it in the OS keyring.
+func loginCommand() *command {
+	return &command{
+		name:  "login",
+		short: "store the password in the OS keyring, it is read from stdin if it is not a terminal",
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			password, err := promptPassword(app)
+			if err != nil {
+				return err
+			}
+
+			app.credentials.set(password)
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			if err := client.RefreshSession(ctx); err != nil {
+				return err
+			}
+
+			if err := keyring.Set(keyringService, app.keyringUser(), password); err != nil {
+				return fmt.Errorf("failed to store password in keyring: %w", err)
+			}
+
+			fmt.Fprintf(app.stderr, "Password of %s stored in the keyring.\n", app.keyringUser())
+
+			return nil
+		},
+	}
+}
+
+// logoutCommand removes a password from the OS keyring.
+func logoutCommand() *command {
+	return &command{
+		name:  "logout",
+		short: "remove the password from the OS keyring",
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			err := keyring.Delete(keyringService, app.keyringUser())
+			if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+				return fmt.Errorf("failed to remove password from keyring: %w", err)
+			}
+
+			fmt.Fprintf(app.stderr, "Password of %s removed from the keyring.\n", app.keyringUser())
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// query is a path in the JSON output of a command, in a subset of the jq and
+// JSONPath syntaxes:
+//
+//	.Data.IPAddress      field of an object
+//	.["Field name"]      field with special characters
+//	.[0] or [0]          element of an array, negative indexes start from the end
+//	.[] or [*]           all the elements of an array or the values of an object
+//
+// The path may start with "$" as in JSONPath. "." is the whole output.
+type query struct {
+	steps []queryStep
+	// Whether the query may return several values.
+	multiple bool
+}
+
+// queryStep is a step of a query, exactly one field is set.
+type queryStep struct {
+	field *string
+	index *int
+	all   bool
+}
+
+// parseQuery parses a query.
+func parseQuery(s string) (*query, error) {
+	q := &query{}
+
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "$")
+	if rest == "" || rest == "." {
+		return q, nil
+	}
+
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".["):
+			rest = rest[1:]
+		case rest[0] == '.':
+			rest = rest[1:]
+
+			if strings.HasPrefix(rest, `"`) {
+				field, n, err := parseQuoted(rest)
+				if err != nil {
+					return nil, err
+				}
+
+				q.steps = append(q.steps, queryStep{field: &field})
+				rest = rest[n:]
+
+				continue
+			}
+
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid query %q: missing field name", s)
+			}
+
+			field := rest[:end]
+			q.steps = append(q.steps, queryStep{field: &field})
+			rest = rest[end:]
+
+			continue
+		case rest[0] != '[':
+			return nil, fmt.Errorf("invalid query %q: unexpected %q", s, rest)
+		}
+
+		// Bracket step.
+		end := strings.IndexByte(rest, ']')
+		if strings.HasPrefix(rest, `["`) {
+			field, n, err := parseQuoted(rest[1:])
+			if err != nil {
+				return nil, err
+			}
+			if !strings.HasPrefix(rest[1+n:], "]") {
+				return nil, fmt.Errorf("invalid query %q: missing ]", s)
+			}
+
+			q.steps = append(q.steps, queryStep{field: &field})
+			rest = rest[n+2:]
+
+			continue
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("invalid query %q: missing ]", s)
+		}
+
+		switch inner := strings.TrimSpace(rest[1:end]); inner {
+		case "", "*":
+			q.steps = append(q.steps, queryStep{all: true})
+			q.multiple = true
+		default:
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: invalid index %q", s, inner)
+			}
+
+			q.steps = append(q.steps, queryStep{index: &index})
+		}
+
+		rest = rest[end+1:]
+	}
+
+	return q, nil
+}
+
+// parseQuoted parses a JSON string at the beginning of s, and returns the
+// number of bytes it used.
+func parseQuoted(s string) (string, int, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+
+	var field string
+	if err := dec.Decode(&field); err != nil {
+		return "", 0, fmt.Errorf("invalid quoted field in query: %w", err)
+	}
+
+	return field, int(dec.InputOffset()), nil
+}
+
+// apply returns the values selected by the query in the JSON encoding of v.
+func (q *query) apply(v any) ([]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	values := []any{doc}
+
+	for _, step := range q.steps {
+		var next []any
+
+		for _, value := range values {
+			switch {
+			case step.field != nil:
+				// Missing fields are null, as in jq.
+				m, _ := value.(map[string]any)
+				next = append(next, m[*step.field])
+			case step.index != nil:
+				a, _ := value.([]any)
+
+				i := *step.index
+				if i < 0 {
+					i += len(a)
+				}
+				if i < 0 || i >= len(a) {
+					next = append(next, nil)
+					continue
+				}
+
+				next = append(next, a[i])
+			default:
+				switch value := value.(type) {
+				case []any:
+					next = append(next, value...)
+				case map[string]any:
+					keys := make([]string, 0, len(value))
+					for k := range value {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+
+					for _, k := range keys {
+						next = append(next, value[k])
+					}
+				}
+			}
+		}
+
+		values = next
+	}
+
+	return values, nil
+}
+
+// result returns the value printed for the result of a query, and
+// whether it is a list of scalars, which are printed one per line.
+func (q *query) result(values []any) (any, bool) {
+	if !q.multiple {
+		if len(values) == 0 {
+			return nil, false
+		}
+
+		return values[0], false
+	}
+
+	for _, v := range values {
+		switch v.(type) {
+		case map[string]any, []any:
+			return values, false
+		}
+	}
+
+	return values, true
+}
+
+// scalarText returns the text of a scalar value, strings are not quoted so
+// that they can be used directly by scripts.
+func scalarText(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "null", true
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case json.Number:
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+)
+
+// rawCommand sends a request to an arbitrary service and method, and prints
+// the response as returned by the Livebox.
+func rawCommand() *command {
+	var (
+		service, method, params string
+		pairs                   []string
+		// Built on the first run, stdin can only be read once with -watch.
+		req *request.Request
+	)
+
+	return &command{
+		name:  "raw",
+		short: "send a request to an arbitrary service and method",
+		read:  true,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&service, "service", "", "service")
+			fs.StringVar(&method, "method", "", "method")
+			fs.StringVar(&params, "params", "", "JSON-encoded params, @file to read them from a file, or - to read them from stdin")
+			fs.Func("param", "param as key=value, the value is decoded as JSON if possible, dots in the key create nested objects (repeatable)", func(s string) error {
+				if !strings.Contains(s, "=") {
+					return fmt.Errorf("expected key=value, got %q", s)
+				}
+
+				pairs = append(pairs, s)
+
+				return nil
+			})
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			if req == nil {
+				var err error
+				if req, err = newRequest(service, method, params, pairs); err != nil {
+					return err
+				}
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			out := json.RawMessage{}
+			if err := client.Request(ctx, req, &out); err != nil {
+				return err
+			}
+
+			// The response is printed as returned by the Livebox.
+			if app.output == outputJSON && app.query == nil {
+				_, err = fmt.Fprintln(app.stdout, string(out))
+				return err
+			}
+
+			return app.print(out)
+		},
+	}
+}
+
+// newRequest builds the request of the raw command. The key=value pairs are
+// set on top of the JSON-encoded params.
+func newRequest(service, method, params string, pairs []string) (*request.Request, error) {
+	if service == "" {
+		return nil, fmt.Errorf("%w: -service is missing", errUsage)
+	}
+
+	if method == "" {
+		return nil, fmt.Errorf("%w: -method is missing", errUsage)
+	}
+
+	b, err := readParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var parameters request.Parameters
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &parameters); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal params: %w", errUsage, err)
+		}
+	}
+
+	for _, pair := range pairs {
+		if parameters == nil {
+			parameters = request.Parameters{}
+		}
+
+		key, value, _ := strings.Cut(pair, "=")
+		if err := setParam(parameters, key, coerce(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &request.Request{
+		Service:    service,
+		Method:     method,
+		Parameters: parameters,
+	}, nil
+}
+
+// readParams returns the JSON-encoded params given to -params, which are read
+// from a file if they start with @, or from stdin if they are "-".
+func readParams(params string) ([]byte, error) {
+	switch {
+	case params == "-":
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read params from stdin: %w", err)
+		}
+
+		return b, nil
+	case strings.HasPrefix(params, "@"):
+		b, err := os.ReadFile(params[1:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read params: %w", errUsage, err)
+		}
+
+		return b, nil
+	default:
+		return []byte(params), nil
+	}
+}
+
+// coerce decodes a value given with -param: JSON values such as numbers,
+// booleans, null, objects and arrays are decoded, other values are strings.
+// A JSON string can be used to pass a number as a string, e.g. key='"42"'.
+func coerce(value string) any {
+	var v any
+	if err := json.Unmarshal([]byte(value), &v); err == nil {
+		return v
+	}
+
+	return value
+}
+
+// setParam sets a param, the dots in the key create nested objects.
+func setParam(params request.Parameters, key string, value any) error {
+	parts := strings.Split(key, ".")
+
+	m := map[string]any(params)
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]any)
+		if !ok {
+			if _, exists := m[part]; exists {
+				return fmt.Errorf("%w: param %q is not an object", errUsage, part)
+			}
+
+			next = map[string]any{}
+			m[part] = next
+		}
+
+		m = next
+	}
+
+	m[parts[len(parts)-1]] = value
+
+	return nil
+}
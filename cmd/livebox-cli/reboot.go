@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// rebootCommand reboots the Livebox, after a confirmation.
+func rebootCommand() *command {
+	var yes bool
+
+	return &command{
+		name:  "reboot",
+		short: "reboot the Livebox",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&yes, "yes", false, "do not ask for confirmation")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			if err := confirm(app, yes, "Reboot the Livebox at "+app.address+"?"); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			return client.Reboot(ctx)
+		},
+	}
+}
+
+// firmwareCommand groups the commands about the firmware of the Livebox.
+func firmwareCommand() *command {
+	var yes bool
+
+	return &command{
+		name:  "firmware",
+		short: "inspect and upgrade the firmware",
+		subs: []*command{
+			{
+				name:  "status",
+				short: "show the version of the firmware",
+				read:  true,
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 0); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					info, err := client.GetDeviceInfo(ctx)
+					if err != nil {
+						return err
+					}
+
+					return app.print(&firmwareStatus{
+						ModelName:       info.ModelName,
+						SoftwareVersion: info.SoftwareVersion,
+						HardwareVersion: info.HardwareVersion,
+						Uptime:          info.Uptime().String(),
+					})
+				},
+			},
+			{
+				name:  "upgrade",
+				short: "install the new firmware, if any, the Livebox reboots if it is upgraded",
+				flags: func(fs *flag.FlagSet) {
+					fs.BoolVar(&yes, "yes", false, "do not ask for confirmation")
+				},
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 0); err != nil {
+						return err
+					}
+
+					if err := confirm(app, yes, "Upgrade the Livebox at "+app.address+", it may reboot?"); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					return client.CheckForUpgrade(ctx)
+				},
+			},
+		},
+	}
+}
+
+// firmwareStatus is printed by the firmware status command.
+type firmwareStatus struct {
+	ModelName       string `json:"ModelName"`
+	SoftwareVersion string `json:"SoftwareVersion"`
+	HardwareVersion string `json:"HardwareVersion"`
+	Uptime          string `json:"Uptime"`
+}
+
+// errNotConfirmed is returned when the user does not confirm an action.
+var errNotConfirmed = errors.New("aborted")
+
+// confirm asks the user to confirm a disruptive action, unless yes is set.
+// Without a terminal, the action must be confirmed with -yes.
+func confirm(app *app, yes bool, question string) error {
+	if yes {
+		return nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("%w: use -yes to confirm", errUsage)
+	}
+
+	fmt.Fprintf(app.stderr, "%s [y/N] ", question)
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errNotConfirmed
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/internal/scrub"
+)
+
+// sensitiveFields are the fields whose values identify the user or the
+// Livebox.
+var sensitiveFields = []string{
+	"serialNumber",
+	"username",
+	"password",
+	"keyPassphrase",
+	"preSharedKey",
+	"wepKey",
+	"ssid",
+	"name",
+	"friendlyName",
+	"hostName",
+	"description",
+	"phoneNumber",
+	"remoteNumber",
+	"directoryNumber",
+}
+
+// Matches MAC addresses in strings.
+var macRe = regexp.MustCompile(`(?i)\b[0-9a-f]{2}(?::[0-9a-f]{2}){5}\b`)
+
+// Match the parts of strings that may be IP addresses, they are checked with
+// netip.ParseAddr.
+var (
+	ipv4Re = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`)
+	ipv6Re = regexp.MustCompile(`[0-9A-Fa-f]*:[0-9A-Fa-f]*:[0-9A-Fa-f:.]*`)
+)
+
+// sanitizer removes the personal data from the values written to a
+// diagnostic bundle. MAC addresses are replaced by aliases, the same address
+// always gets the same alias so that the devices can still be followed across
+// the files of the bundle.
+type sanitizer struct {
+	scrubber *scrub.Scrubber
+	macs     map[string]string
+}
+
+// newSanitizer returns a sanitizer.
+func newSanitizer() *sanitizer {
+	s := &sanitizer{
+		scrubber: scrub.New("REDACTED", sensitiveFields...),
+		macs:     map[string]string{},
+	}
+	s.scrubber.Strings = s.sanitizeString
+
+	return s
+}
+
+// sanitize returns a copy of a JSON-decoded value without the values of the
+// sensitive fields, the MAC addresses and the public IP addresses.
+func (s *sanitizer) sanitize(v any) any {
+	return s.scrubber.Value(v)
+}
+
+// sanitizeString returns a string without its MAC addresses and its public IP
+// addresses.
+func (s *sanitizer) sanitizeString(v string) string {
+	v = macRe.ReplaceAllStringFunc(v, s.alias)
+	v = ipv4Re.ReplaceAllStringFunc(v, s.redactPublic)
+
+	return ipv6Re.ReplaceAllStringFunc(v, s.redactPublic)
+}
+
+// redactPublic returns the replacement if s is a public IP address, and s
+// otherwise.
+func (s *sanitizer) redactPublic(v string) string {
+	if addr, err := netip.ParseAddr(v); err == nil && isPublic(addr) {
+		return s.scrubber.Replacement
+	}
+
+	return v
+}
+
+// sanitizeError returns the message of an error without personal data. The
+// info of the API errors, that may name a device or a host, is removed.
+func (s *sanitizer) sanitizeError(err error) string {
+	msg := err.Error()
+
+	var apiErrs []*response.Error
+
+	var multi *response.Errors
+	var single *response.Error
+
+	switch {
+	case errors.As(err, &multi):
+		apiErrs = multi.Errors
+	case errors.As(err, &single):
+		apiErrs = []*response.Error{single}
+	}
+
+	for _, apiErr := range apiErrs {
+		if apiErr.Info != "" {
+			msg = strings.ReplaceAll(msg, "Info: "+apiErr.Info, "Info: "+s.scrubber.Replacement)
+		}
+	}
+
+	return s.sanitize(msg).(string)
+}
+
+// alias returns the alias of a MAC address, a locally administered address
+// numbered in order of appearance.
+func (s *sanitizer) alias(mac string) string {
+	mac = strings.ToUpper(mac)
+
+	if alias, ok := s.macs[mac]; ok {
+		return alias
+	}
+
+	n := len(s.macs) + 1
+	alias := fmt.Sprintf("02:00:00:%02X:%02X:%02X", n>>16&0xff, n>>8&0xff, n&0xff)
+	s.macs[mac] = alias
+
+	return alias
+}
+
+// isPublic returns whether an IP address is routable on the internet.
+func isPublic(addr netip.Addr) bool {
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/metrics"
+	"github.com/Tomy2e/livebox-api-client/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Time given to the pending scrapes when the exporter stops.
+const shutdownTimeout = 5 * time.Second
+
+// serveCommand runs a Prometheus exporter for the state of the Livebox and
+// the statistics of the client, and optionally the REST gateway and the
+// debugging endpoints, until it is interrupted.
+func serveCommand() *command {
+	var (
+		listen, path, tokenFile string
+		debug                   bool
+	)
+
+	return &command{
+		name:  "serve",
+		short: "run a Prometheus exporter, and optionally a REST gateway under /api/, for the Livebox until interrupted",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&listen, "listen", ":9118", "address to listen on")
+			fs.StringVar(&path, "path", "/metrics", "path of the metrics")
+			fs.StringVar(&tokenFile, "api-token-file", "", "file containing the bearer token of the REST gateway, which is disabled if unset")
+			fs.BoolVar(&debug, "debug", false, "serve the internals of the client under /debug/vars, and profiles under /debug/pprof/")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(
+				metrics.NewCollector(client),
+				metrics.NewLiveboxCollector(client),
+			)
+
+			mux := http.NewServeMux()
+			mux.Handle(path, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+			if tokenFile != "" {
+				token, err := os.ReadFile(tokenFile)
+				if err != nil {
+					return err
+				}
+
+				api, err := server.New(client, strings.TrimSpace(string(token)))
+				if err != nil {
+					return fmt.Errorf("%w: %w", errUsage, err)
+				}
+
+				mux.Handle("/api/", http.StripPrefix("/api", api))
+			}
+
+			if debug {
+				expvar.Publish("livebox", client.Expvar())
+
+				mux.Handle("/debug/vars", expvar.Handler())
+				mux.HandleFunc("/debug/pprof/", pprof.Index)
+				mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+				mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+				mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+				mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+			}
+
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/" {
+					http.NotFound(w, r)
+					return
+				}
+
+				fmt.Fprintf(w, "<html><body><a href=%q>Metrics</a></body></html>\n", path)
+			})
+
+			srv := &http.Server{
+				Addr:              listen,
+				Handler:           mux,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			errc := make(chan error, 1)
+			go func() { errc <- srv.ListenAndServe() }()
+
+			fmt.Fprintf(app.stderr, "Serving metrics on %s%s\n", listen, path)
+
+			select {
+			case err := <-errc:
+				return err
+			case <-ctx.Done():
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+
+			if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
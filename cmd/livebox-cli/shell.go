@@ -0,0 +1,430 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"golang.org/x/term"
+)
+
+// shellHelp is printed by the help command of the shell.
+const shellHelp = `Commands:
+  <service> <method> [key=value...] ['{json}']  send a request, e.g. NeMo.Intf.data getMIBs mibs=ppp
+  ls [service]                                  list the objects and the functions of a service
+  help                                          print this help
+  exit                                          leave the shell (or Ctrl-D)
+
+Tab completes the services, the methods and their arguments. The up and down
+arrows browse the history of the session.
+`
+
+// shellCommand starts an interactive shell to explore the API of the Livebox.
+func shellCommand() *command {
+	return &command{
+		name:  "shell",
+		short: "explore the API of the Livebox interactively, with completion of the services and methods",
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			// The password may be prompted, it must be done before the
+			// terminal is put in raw mode.
+			if err := client.RefreshSession(ctx); err != nil {
+				return err
+			}
+
+			sh := &shell{
+				ctx:     ctx,
+				app:     app,
+				client:  client,
+				objects: make(map[string]*response.Object),
+			}
+
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return sh.runScript(os.Stdin)
+			}
+
+			return sh.runTerminal()
+		},
+	}
+}
+
+// shell reads commands from a terminal or a script, and sends the requests
+// to the Livebox.
+type shell struct {
+	ctx    context.Context
+	app    *app
+	client *livebox.Client
+	// Nil when the commands are read from a script.
+	term *term.Terminal
+	// Objects introspected for the completion, by path. Objects that could
+	// not be introspected are nil.
+	objects map[string]*response.Object
+}
+
+// runTerminal reads the commands from the terminal until the user exits.
+func (s *shell) runTerminal() error {
+	fd := int(os.Stdin.Fd())
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to set terminal in raw mode: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	s.term = term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, s.app.stdout}, "livebox> ")
+	s.term.AutoCompleteCallback = s.complete
+
+	if width, height, err := term.GetSize(fd); err == nil && width > 0 {
+		_ = s.term.SetSize(width, height)
+	}
+
+	// The terminal translates line feeds while it is in raw mode.
+	out := s.app.stdout
+	s.app.stdout = s.term
+	defer func() { s.app.stdout = out }()
+
+	fmt.Fprintln(s.term, `Type "help" for help.`)
+
+	for {
+		line, err := s.term.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if exit := s.exec(line); exit {
+			return nil
+		}
+	}
+}
+
+// runScript runs the commands read from r, one per line.
+func (s *shell) runScript(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if exit := s.exec(scanner.Text()); exit {
+			return nil
+		}
+	}
+
+	return scanner.Err()
+}
+
+// exec runs a command line, and returns whether the shell must exit. Errors
+// are printed and do not stop the shell.
+func (s *shell) exec(line string) bool {
+	words, err := splitWords(line)
+	if err != nil {
+		fmt.Fprintf(s.app.stdout, "Error: %s\n", err)
+		return false
+	}
+
+	if len(words) == 0 || strings.HasPrefix(words[0], "#") {
+		return false
+	}
+
+	switch words[0] {
+	case "exit", "quit":
+		return true
+	case "help":
+		fmt.Fprint(s.app.stdout, shellHelp)
+	case "ls":
+		err = s.list(words[1:])
+	default:
+		err = s.request(words)
+	}
+
+	if err != nil {
+		fmt.Fprintf(s.app.stdout, "Error: %s\n", err)
+	}
+
+	return false
+}
+
+// request sends a request, the words are the service, the method and the
+// params, and prints the response.
+func (s *shell) request(words []string) error {
+	if len(words) < 2 {
+		return errors.New("expected a service and a method")
+	}
+
+	var (
+		params string
+		pairs  []string
+	)
+
+	for _, word := range words[2:] {
+		switch {
+		case strings.HasPrefix(word, "{"):
+			if params != "" {
+				return errors.New("params can only be given once as JSON")
+			}
+
+			params = word
+		case strings.Contains(word, "="):
+			pairs = append(pairs, word)
+		default:
+			return fmt.Errorf("expected key=value or JSON params, got %q", word)
+		}
+	}
+
+	req, err := newRequest(words[0], words[1], params, pairs)
+	if err != nil {
+		return err
+	}
+
+	out := json.RawMessage{}
+	if err := s.client.Request(s.ctx, req, &out); err != nil {
+		return err
+	}
+
+	return s.app.print(out)
+}
+
+// list prints the objects and the functions of a service, or the top-level
+// objects.
+func (s *shell) list(args []string) error {
+	var path string
+	switch len(args) {
+	case 0:
+	case 1:
+		path = args[0]
+	default:
+		return errors.New("expected at most one service")
+	}
+
+	obj, err := s.client.Introspect(s.ctx, path, 1)
+	if err != nil {
+		return err
+	}
+
+	s.objects[path] = obj
+
+	for _, child := range obj.Children {
+		fmt.Fprintf(s.app.stdout, "%s.\n", child.ObjectInfo.Key)
+	}
+
+	for _, fn := range obj.Functions {
+		var args []string
+		for _, arg := range fn.Arguments {
+			switch {
+			case !arg.Attributes.In:
+			case arg.Attributes.Mandatory:
+				args = append(args, arg.Name)
+			default:
+				args = append(args, "["+arg.Name+"]")
+			}
+		}
+
+		fmt.Fprintf(s.app.stdout, "%s(%s)\n", fn.Name, strings.Join(args, ", "))
+	}
+
+	return nil
+}
+
+// object returns an object and its direct children, it is introspected on
+// first use. It returns nil if the object could not be introspected.
+func (s *shell) object(path string) *response.Object {
+	if obj, ok := s.objects[path]; ok {
+		return obj
+	}
+
+	obj, err := s.client.Introspect(s.ctx, path, 1)
+	if err != nil {
+		obj = nil
+	}
+
+	s.objects[path] = obj
+
+	return obj
+}
+
+// complete is the completion callback of the terminal, it completes the word
+// under the cursor when tab is pressed. The possible values are printed when
+// the word cannot be completed further.
+func (s *shell) complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	head := line[:pos]
+	words := strings.Fields(head)
+
+	var prefix string
+	if len(words) > 0 && !strings.HasSuffix(head, " ") {
+		prefix = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var matches []string
+	for _, candidate := range s.candidates(words, prefix) {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	if len(matches) == 0 {
+		return line, pos, true
+	}
+
+	completed := commonPrefix(matches)
+	if len(matches) == 1 && !strings.HasSuffix(completed, "=") {
+		completed += " "
+	}
+
+	if completed == prefix {
+		fmt.Fprintln(s.term, strings.Join(matches, "  "))
+		return line, pos, true
+	}
+
+	head = head[:len(head)-len(prefix)] + completed
+
+	return head + line[pos:], len(head), true
+}
+
+// candidates returns the possible values of the word following words.
+func (s *shell) candidates(words []string, prefix string) []string {
+	switch {
+	case len(words) == 0:
+		return append(s.services(prefix), "exit", "help", "ls", "quit")
+	case len(words) == 1 && words[0] == "ls":
+		return s.services(prefix)
+	case len(words) == 1:
+		obj := s.object(words[0])
+		if obj == nil {
+			return nil
+		}
+
+		names := make([]string, len(obj.Functions))
+		for i, fn := range obj.Functions {
+			names[i] = fn.Name
+		}
+
+		return names
+	}
+
+	obj := s.object(words[0])
+	if obj == nil {
+		return nil
+	}
+
+	i := slices.IndexFunc(obj.Functions, func(fn response.Function) bool { return fn.Name == words[1] })
+	if i < 0 {
+		return nil
+	}
+
+	var names []string
+	for _, arg := range obj.Functions[i].Arguments {
+		if arg.Attributes.In && !slices.ContainsFunc(words[2:], func(w string) bool { return strings.HasPrefix(w, arg.Name+"=") }) {
+			names = append(names, arg.Name+"=")
+		}
+	}
+
+	return names
+}
+
+// services returns the paths of the children of the object whose path is
+// before the last dot of prefix.
+func (s *shell) services(prefix string) []string {
+	parent, _, ok := cutLast(prefix, ".")
+
+	obj := s.object(parent)
+	if obj == nil {
+		return nil
+	}
+
+	paths := make([]string, len(obj.Children))
+	for i, child := range obj.Children {
+		paths[i] = child.ObjectInfo.Key
+		if ok {
+			paths[i] = parent + "." + paths[i]
+		}
+	}
+
+	return paths
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+
+	return "", s, false
+}
+
+// commonPrefix returns the longest common prefix of values.
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	return prefix
+}
+
+// splitWords splits a command line in words separated by spaces. Single or
+// double quotes may be used to include spaces in a word.
+func splitWords(line string) ([]string, error) {
+	var (
+		words []string
+		word  strings.Builder
+		// Whether a word was started, it may be empty when quoted.
+		inWord bool
+		quote  rune
+	)
+
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
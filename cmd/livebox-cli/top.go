@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"golang.org/x/term"
+)
+
+// rate is the throughput of an interface or a device, in bytes per second.
+// Rx is the traffic received by the Livebox, Tx the traffic it sent.
+type rate struct {
+	rx, tx float64
+}
+
+// topCommand shows the throughput of the interfaces and the devices of the
+// home network, refreshed periodically.
+func topCommand() *command {
+	var interval time.Duration
+
+	return &command{
+		name:  "top",
+		short: "show the live throughput of the interfaces and the devices, RX is received by the Livebox",
+		flags: func(fs *flag.FlagSet) {
+			fs.DurationVar(&interval, "interval", 3*time.Second, "refresh interval")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			if interval <= 0 {
+				return fmt.Errorf("%w: the interval must be positive", errUsage)
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			t := &top{
+				client:      client,
+				names:       map[string]string{},
+				intfRates:   map[string]rate{},
+				deviceRates: map[string]rate{},
+			}
+
+			return t.run(ctx, app.stdout, interval)
+		},
+	}
+}
+
+// top computes the throughputs from the differences between the counters
+// of successive refreshes.
+type top struct {
+	client *livebox.Client
+	// Names of the devices by MAC address.
+	names map[string]string
+
+	// Counters of the previous refresh.
+	intfCounters   map[string]response.InterfaceCounters
+	deviceCounters map[string]response.DeviceCounters
+	refreshed      time.Time
+
+	intfRates   map[string]rate
+	deviceRates map[string]rate
+}
+
+// run refreshes the throughputs every interval until ctx is canceled. On a
+// terminal, the screen is redrawn, otherwise the throughputs are written
+// again with the time.
+func (t *top) run(ctx context.Context, out io.Writer, interval time.Duration) error {
+	tty := isTerminal(out)
+
+	if devices, err := t.client.Devices().List(ctx); err == nil {
+		for _, d := range devices {
+			t.names[strings.ToUpper(d.PhysAddress)] = d.Name
+		}
+	}
+
+	for {
+		errs := t.refresh(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if tty {
+			fmt.Fprint(out, clearScreen)
+			fmt.Fprintf(out, "Every %s: livebox-cli top\t%s\n\n", interval, time.Now().Format(time.DateTime))
+		} else {
+			fmt.Fprintf(out, "--- %s\n", time.Now().Format(time.RFC3339))
+		}
+
+		for _, err := range errs {
+			fmt.Fprintf(out, "Error: %s\n", err)
+		}
+
+		// The devices that do not fit on the screen are not written, below
+		// the header, the errors and the table of interfaces.
+		maxDevices := -1
+		if f, ok := out.(*os.File); ok && tty {
+			if _, height, err := term.GetSize(int(f.Fd())); err == nil {
+				maxDevices = max(0, height-len(errs)-len(t.intfCounters)-7)
+			}
+		}
+
+		t.write(out, maxDevices)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// refresh gets the counters and updates the throughputs. Devices counters are
+// recorded periodically by the Livebox, their throughputs are only updated
+// when a new reading is available.
+func (t *top) refresh(ctx context.Context) []error {
+	var errs []error
+
+	now := time.Now()
+
+	intfs, err := t.client.GetInterfaceCounters(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(intfs) > 0 {
+		counters := make(map[string]response.InterfaceCounters, len(intfs))
+		for _, c := range intfs {
+			counters[c.Interface] = c
+
+			if prev, ok := t.intfCounters[c.Interface]; ok {
+				t.intfRates[c.Interface] = rateOf(prev.RxBytes, c.RxBytes, prev.TxBytes, c.TxBytes, now.Sub(t.refreshed))
+			}
+		}
+
+		t.intfCounters = counters
+		t.refreshed = now
+	}
+
+	devices, err := t.client.GetDeviceCounters(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get counters of devices: %w", err))
+	}
+
+	if len(devices) > 0 {
+		counters := make(map[string]response.DeviceCounters, len(devices))
+		for _, c := range devices {
+			counters[c.MACAddress] = c
+
+			if prev, ok := t.deviceCounters[c.MACAddress]; ok && c.Timestamp > prev.Timestamp {
+				t.deviceRates[c.MACAddress] = rateOf(prev.RxBytes, c.RxBytes, prev.TxBytes, c.TxBytes, time.Duration(c.Timestamp-prev.Timestamp)*time.Second)
+			} else if ok {
+				// Keep the previous reading to compute the throughput
+				// when the next one is available.
+				counters[c.MACAddress] = prev
+			}
+		}
+
+		t.deviceCounters = counters
+	}
+
+	return errs
+}
+
+// write writes the throughputs of the interfaces and the devices, the busiest
+// first. At most maxDevices devices are written, if it is not negative.
+func (t *top) write(out io.Writer, maxDevices int) {
+	intfs := make([]string, 0, len(t.intfCounters))
+	for intf := range t.intfCounters {
+		intfs = append(intfs, intf)
+	}
+	sortByRate(intfs, t.intfRates)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "INTERFACE\tRX\tTX")
+	for _, intf := range intfs {
+		rx, tx := formatRates(t.intfRates, intf)
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", intf, rx, tx)
+	}
+	tw.Flush()
+
+	devices := make([]string, 0, len(t.deviceCounters))
+	for mac := range t.deviceCounters {
+		devices = append(devices, mac)
+	}
+	sortByRate(devices, t.deviceRates)
+
+	if maxDevices >= 0 && len(devices) > maxDevices {
+		devices = devices[:maxDevices]
+	}
+
+	fmt.Fprintln(out)
+
+	tw = tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "DEVICE\tMAC\tRX\tTX")
+	for _, mac := range devices {
+		rx, tx := formatRates(t.deviceRates, mac)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", t.names[mac], mac, rx, tx)
+	}
+	tw.Flush()
+}
+
+// rateOf returns the throughput between two readings of counters, taken d
+// apart. Counters that were reset have no throughput.
+func rateOf(prevRx, rx, prevTx, tx uint64, d time.Duration) rate {
+	if d <= 0 {
+		return rate{}
+	}
+
+	var r rate
+	if rx >= prevRx {
+		r.rx = float64(rx-prevRx) / d.Seconds()
+	}
+	if tx >= prevTx {
+		r.tx = float64(tx-prevTx) / d.Seconds()
+	}
+
+	return r
+}
+
+// sortByRate sorts keys by decreasing total throughput, then by name.
+func sortByRate(keys []string, rates map[string]rate) {
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := rates[keys[i]], rates[keys[j]]
+		if ti, tj := ri.rx+ri.tx, rj.rx+rj.tx; ti != tj {
+			return ti > tj
+		}
+
+		return keys[i] < keys[j]
+	})
+}
+
+// formatRates returns the received and sent throughputs of key, they are
+// empty until two readings were made.
+func formatRates(rates map[string]rate, key string) (string, string) {
+	r, ok := rates[key]
+	if !ok {
+		return "-", "-"
+	}
+
+	return formatRate(r.rx), formatRate(r.tx)
+}
+
+// formatRate formats a throughput in bits per second, as network tools do.
+func formatRate(bytesPerSecond float64) string {
+	bits := bytesPerSecond * 8
+
+	for _, unit := range []string{"b/s", "kb/s", "Mb/s"} {
+		if bits < 1000 {
+			return fmt.Sprintf("%.1f %s", bits, unit)
+		}
+
+		bits /= 1000
+	}
+
+	return fmt.Sprintf("%.1f Gb/s", bits)
+}
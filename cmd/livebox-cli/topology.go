@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// topologyCommand prints the topology of the home network as a tree, or as a
+// Graphviz graph.
+func topologyCommand() *command {
+	var (
+		format string
+		active bool
+	)
+
+	return &command{
+		name:  "topology",
+		short: "show what is connected where, as a tree or a Graphviz graph (e.g. -format dot | dot -Tsvg)",
+		read:  true,
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&format, "format", "tree", "format of the topology: tree or dot")
+			fs.BoolVar(&active, "active", false, "only show the connected devices")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			if format != "tree" && format != "dot" {
+				return fmt.Errorf("%w: unknown format %q, expected tree or dot", errUsage, format)
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			root, err := client.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+
+			if active {
+				pruneInactive(root)
+			}
+
+			switch {
+			case app.query != nil:
+				return app.print(root)
+			case format == "dot":
+				writeDOT(app.stdout, root)
+			default:
+				fmt.Fprintln(app.stdout, nodeLabel(root))
+				writeTree(app.stdout, root.Children, "")
+			}
+
+			return nil
+		},
+	}
+}
+
+// pruneInactive removes the inactive descendants of a node.
+func pruneInactive(node *response.TopologyNode) {
+	children := node.Children[:0]
+	for _, child := range node.Children {
+		if child.Active {
+			pruneInactive(child)
+			children = append(children, child)
+		}
+	}
+
+	node.Children = children
+}
+
+// writeTree writes nodes and their descendants as the branches of a tree,
+// prefix is written before the branches of the nodes.
+func writeTree(w io.Writer, nodes []*response.TopologyNode, prefix string) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		label := nodeLabel(node)
+		if node.InterfaceName != "" {
+			label = node.InterfaceName + ": " + label
+		}
+
+		fmt.Fprintln(w, prefix+branch+label)
+		writeTree(w, node.Children, prefix+indent)
+	}
+}
+
+// nodeLabel returns the name of a node followed by its type and IP address.
+func nodeLabel(node *response.TopologyNode) string {
+	name := node.Name
+	if name == "" {
+		name = node.Key
+	}
+
+	var details []string
+	for _, detail := range []string{node.DeviceType, node.IPAddress} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+
+	if len(details) > 0 {
+		name += " (" + strings.Join(details, ", ") + ")"
+	}
+
+	if !node.Active {
+		name += " [inactive]"
+	}
+
+	return name
+}
+
+// dotEscaper escapes the strings quoted in a Graphviz file.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// writeDOT writes the topology as a Graphviz graph. Edges are labeled with the
+// interface of the parent, inactive nodes are dashed.
+func writeDOT(w io.Writer, root *response.TopologyNode) {
+	fmt.Fprintln(w, "digraph topology {")
+	fmt.Fprintln(w, "\trankdir=LR;")
+	fmt.Fprintln(w, "\tnode [shape=box];")
+
+	// Nodes are identified by their position, keys may be missing.
+	ids := map[*response.TopologyNode]string{}
+
+	root.Walk(func(node *response.TopologyNode, _ int) bool {
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[node] = id
+
+		name := node.Name
+		if name == "" {
+			name = node.Key
+		}
+
+		var lines []string
+		for _, line := range []string{name, node.DeviceType, node.IPAddress} {
+			if line != "" {
+				lines = append(lines, dotEscaper.Replace(line))
+			}
+		}
+
+		style := ""
+		if !node.Active {
+			style = ", style=dashed"
+		}
+
+		fmt.Fprintf(w, "\t%s [label=\"%s\"%s];\n", id, strings.Join(lines, `\n`), style)
+
+		return true
+	})
+
+	root.Walk(func(node *response.TopologyNode, _ int) bool {
+		for _, child := range node.Children {
+			fmt.Fprintf(w, "\t%s -> %s [label=\"%s\"];\n", ids[node], ids[child], dotEscaper.Replace(child.InterfaceName))
+		}
+
+		return true
+	})
+
+	fmt.Fprintln(w, "}")
+}
@@ -0,0 +1,73 @@
+package main
+
+import "context"
+
+// wanCommand groups the commands about the WAN connection.
+func wanCommand() *command {
+	return &command{
+		name:  "wan",
+		short: "inspect the WAN connection",
+		subs: []*command{
+			{
+				name:  "status",
+				short: "show the status of the WAN connection",
+				read:  true,
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 0); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					status, err := client.GetWANStatus(ctx)
+					if err != nil {
+						return err
+					}
+
+					return app.print(status)
+				},
+			},
+			{
+				name:  "mode",
+				short: "show the mode and access technology of the WAN connection",
+				read:  true,
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 0); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					mode, err := client.GetWANMode(ctx)
+					if err != nil {
+						return err
+					}
+
+					return app.print(mode)
+				},
+			},
+			{
+				name:  "reconnect",
+				short: "reconnect the WAN connection to get a new public IP address",
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 0); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					return client.ReconnectWAN(ctx)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used to refresh the terminal.
+const (
+	clearScreen  = "\033[H\033[2J"
+	reverseVideo = "\033[7m"
+	resetVideo   = "\033[0m"
+)
+
+// watch runs a read command every interval until ctx is canceled. On a
+// terminal, the screen is refreshed and the lines that changed since the
+// previous run are highlighted. Otherwise, the output is written again, with
+// the time, each time it changes. Errors are displayed and do not stop the
+// command, so that it can be used to follow an outage.
+func watch(ctx context.Context, app *app, interval time.Duration, title string, run func() error) error {
+	out := app.stdout
+	defer func() { app.stdout = out }()
+
+	tty := isTerminal(out)
+
+	var previous []string
+
+	for {
+		var buf bytes.Buffer
+		app.stdout = &buf
+
+		if err := run(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			fmt.Fprintf(&buf, "Error: %s\n", err)
+		}
+
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+
+		switch {
+		case tty:
+			fmt.Fprint(out, clearScreen)
+			fmt.Fprintf(out, "Every %s: %s\t%s\n\n", interval, title, time.Now().Format(time.DateTime))
+			writeHighlighted(out, lines, previous)
+		case !slices.Equal(lines, previous):
+			fmt.Fprintf(out, "--- %s\n", time.Now().Format(time.RFC3339))
+			fmt.Fprintln(out, strings.Join(lines, "\n"))
+		}
+
+		previous = lines
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// writeHighlighted writes lines, the ones that differ from the line at the
+// same position in previous are highlighted. Nothing is highlighted on the
+// first run.
+func writeHighlighted(w io.Writer, lines, previous []string) {
+	for i, line := range lines {
+		if previous != nil && (i >= len(previous) || previous[i] != line) {
+			fmt.Fprintln(w, reverseVideo+line+resetVideo)
+			continue
+		}
+
+		fmt.Fprintln(w, line)
+	}
+}
+
+// isTerminal returns whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
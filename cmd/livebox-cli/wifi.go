@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// wifiCommand groups the commands that manage the Wi-Fi.
+func wifiCommand() *command {
+	return &command{
+		name:  "wifi",
+		short: "manage the Wi-Fi",
+		subs: []*command{
+			wifiStatusCommand(),
+			wifiSetSSIDCommand(),
+			wifiSetPasswordCommand(),
+			wifiEnableCommand("enable", "enable the Wi-Fi", true),
+			wifiEnableCommand("disable", "disable the Wi-Fi", false),
+			wifiGuestCommand(),
+			{
+				name:  "wps",
+				short: "start a WPS pairing session",
+				run: func(ctx context.Context, app *app, args []string) error {
+					if err := exactArgs(args, 0); err != nil {
+						return err
+					}
+
+					client, err := app.client()
+					if err != nil {
+						return err
+					}
+
+					return client.StartWPS(ctx)
+				},
+			},
+		},
+	}
+}
+
+// wifiStatus is printed by the wifi status command.
+type wifiStatus struct {
+	Enable       bool                       `json:"Enable"`
+	GuestEnable  bool                       `json:"GuestEnable"`
+	AccessPoints []response.WiFiAccessPoint `json:"AccessPoints"`
+}
+
+// wifiStatusCommand prints the state of the Wi-Fi and its access points.
+func wifiStatusCommand() *command {
+	return &command{
+		name:  "status",
+		short: "show the state of the Wi-Fi and its access points",
+		read:  true,
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			status, err := client.GetWiFiStatus(ctx)
+			if err != nil {
+				return err
+			}
+
+			guest, err := client.GetGuestWiFi(ctx)
+			if err != nil {
+				return err
+			}
+
+			aps, err := client.GetWiFiAccessPoints(ctx)
+			if err != nil {
+				return err
+			}
+
+			if app.output == outputTable || app.output == outputWide {
+				return printList(app, aps, accessPointColumns)
+			}
+
+			return app.print(&wifiStatus{
+				Enable:       status.Enable,
+				GuestEnable:  guest,
+				AccessPoints: aps,
+			})
+		},
+	}
+}
+
+// accessPointColumns are the columns of the table of access points.
+var accessPointColumns = []column[response.WiFiAccessPoint]{
+	{header: "NAME", value: func(ap response.WiFiAccessPoint) string { return ap.Name }},
+	{header: "SSID", value: func(ap response.WiFiAccessPoint) string { return ap.SSID }},
+	{header: "STATUS", value: func(ap response.WiFiAccessPoint) string { return ap.VAPStatus }},
+	{header: "SECURITY", value: func(ap response.WiFiAccessPoint) string { return ap.Security.ModeEnabled }},
+	{header: "GUEST", value: func(ap response.WiFiAccessPoint) string { return strconv.FormatBool(ap.Guest()) }},
+	{header: "BSSID", wide: true, value: func(ap response.WiFiAccessPoint) string { return ap.BSSID }},
+	{header: "BROADCAST", wide: true, value: func(ap response.WiFiAccessPoint) string {
+		return strconv.FormatBool(ap.SSIDAdvertisementEnabled)
+	}},
+	{header: "WPS", wide: true, value: func(ap response.WiFiAccessPoint) string { return strconv.FormatBool(ap.WPS.Enable) }},
+}
+
+// guestAccessPoints returns the names of the access points of the guest
+// network.
+func guestAccessPoints(ctx context.Context, client *livebox.Client) ([]string, error) {
+	aps, err := client.GetWiFiAccessPoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, ap := range aps {
+		if ap.Guest() {
+			names = append(names, ap.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, errors.New("no guest access point found")
+	}
+
+	return names, nil
+}
+
+// wifiSetSSIDCommand sets the SSID of the main or guest network.
+func wifiSetSSIDCommand() *command {
+	var guest bool
+
+	return &command{
+		name:  "set-ssid",
+		args:  "<ssid>",
+		short: "set the SSID of the Wi-Fi network",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&guest, "guest", false, "set the SSID of the guest network")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 1); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			var aps []string
+			if guest {
+				if aps, err = guestAccessPoints(ctx, client); err != nil {
+					return err
+				}
+			}
+
+			return client.SetWiFiSSID(ctx, args[0], aps...)
+		},
+	}
+}
+
+// wifiSetPasswordCommand sets the passphrase of the main or guest network. It
+// is prompted if it is not given as argument, so that it does not end up in
+// the shell history.
+func wifiSetPasswordCommand() *command {
+	var guest bool
+
+	return &command{
+		name:  "set-password",
+		args:  "[password]",
+		short: "set the passphrase of the Wi-Fi network, it is prompted if not given",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&guest, "guest", false, "set the passphrase of the guest network")
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if len(args) > 1 {
+				return exactArgs(args, 1)
+			}
+
+			var password string
+			if len(args) == 1 {
+				password = args[0]
+			} else {
+				var err error
+				if password, err = promptSecret(app, "Wi-Fi passphrase"); err != nil {
+					return err
+				}
+			}
+
+			// WPA passphrases must have between 8 and 63 characters.
+			if len(password) < 8 || len(password) > 63 {
+				return fmt.Errorf("%w: the passphrase must have between 8 and 63 characters", errUsage)
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			var aps []string
+			if guest {
+				if aps, err = guestAccessPoints(ctx, client); err != nil {
+					return err
+				}
+			}
+
+			return client.SetWiFiPassword(ctx, password, aps...)
+		},
+	}
+}
+
+// wifiEnableCommand enables or disables the Wi-Fi.
+func wifiEnableCommand(name, short string, enable bool) *command {
+	return &command{
+		name:  name,
+		short: short,
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			return client.SetWiFiEnabled(ctx, enable)
+		},
+	}
+}
+
+// wifiGuestCommand shows, enables or disables the guest network.
+func wifiGuestCommand() *command {
+	guestCommand := func(name, short string, enable bool) *command {
+		return &command{
+			name:  name,
+			short: short,
+			run: func(ctx context.Context, app *app, args []string) error {
+				if err := exactArgs(args, 0); err != nil {
+					return err
+				}
+
+				client, err := app.client()
+				if err != nil {
+					return err
+				}
+
+				return client.SetGuestWiFiEnabled(ctx, enable)
+			},
+		}
+	}
+
+	return &command{
+		name:  "guest",
+		short: "show whether the guest network is enabled, or enable or disable it",
+		read:  true,
+		subs: []*command{
+			guestCommand("enable", "enable the guest network", true),
+			guestCommand("disable", "disable the guest network", false),
+		},
+		run: func(ctx context.Context, app *app, args []string) error {
+			if err := exactArgs(args, 0); err != nil {
+				return err
+			}
+
+			client, err := app.client()
+			if err != nil {
+				return err
+			}
+
+			enable, err := client.GetGuestWiFi(ctx)
+			if err != nil {
+				return err
+			}
+
+			return app.print(map[string]bool{"Enable": enable})
+		},
+	}
+}
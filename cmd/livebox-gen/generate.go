@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// binding describes the code generated for a function of an object.
+type binding struct {
+	// Name of the generated function.
+	Name    string
+	Service string
+	Method  string
+	// Go type of the value returned by the function.
+	ReturnType string
+	// Datamodel type of the value returned by the function.
+	Returns string
+	In      []field
+	Out     []field
+}
+
+// field is a field of a generated struct.
+type field struct {
+	Name     string
+	JSONName string
+	Type     string
+	// Datamodel type of the field.
+	DataType string
+	Optional bool
+}
+
+var tmpl = template.Must(template.New("bindings").Parse(`// Code generated by livebox-gen; DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+var (
+	_ = request.Parameters{}
+	_ response.Status[any]
+)
+{{ range .Bindings }}
+// {{ .Name }}Params are the input arguments of {{ .Service }}:{{ .Method }}.
+type {{ .Name }}Params struct {
+{{- range .In }}
+	{{ .Name }} {{ if .Optional }}*{{ end }}{{ .Type }}
+{{- end }}
+}
+
+// {{ .Name }}Data are the output arguments of {{ .Service }}:{{ .Method }}.
+type {{ .Name }}Data struct {
+{{- range .Out }}
+	{{ .Name }} {{ .Type }} ` + "`json:\"{{ .JSONName }}\"`" + `
+{{- end }}
+}
+
+// {{ .Name }} calls {{ .Service }}:{{ .Method }}.
+func {{ .Name }}(ctx context.Context, c *livebox.Client, params *{{ .Name }}Params) ({{ .ReturnType }}, *{{ .Name }}Data, error) {
+	p := request.Parameters{}
+	{{- if .In }}
+	if params != nil {
+	{{- range .In }}
+		{{- if .Optional }}
+		if params.{{ .Name }} != nil {
+			p["{{ .JSONName }}"] = *params.{{ .Name }}
+		}
+		{{- else }}
+		p["{{ .JSONName }}"] = params.{{ .Name }}
+		{{- end }}
+	{{- end }}
+	}
+	{{- end }}
+
+	var out response.StatusData[{{ .ReturnType }}, {{ .Name }}Data]
+	if err := c.Request(ctx, request.New("{{ .Service }}", "{{ .Method }}", p), &out); err != nil {
+		var zero {{ .ReturnType }}
+		return zero, nil, err
+	}
+
+	return out.Status, &out.Data, nil
+}
+{{ end }}`))
+
+// generate returns the Go source code of the bindings for the functions of
+// the object and its children.
+func generate(pkg string, obj *response.Object) ([]byte, error) {
+	bindings := sortedBindings(obj)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{
+		"Package":  pkg,
+		"Bindings": bindings,
+	}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// sortedBindings returns the bindings of the functions of the object and its
+// children, sorted by name.
+func sortedBindings(obj *response.Object) []binding {
+	var bindings []binding
+	collect(obj, &bindings)
+
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].Name < bindings[j].Name })
+
+	return bindings
+}
+
+// collect appends the bindings of the functions of the object and its
+// children.
+func collect(obj *response.Object, bindings *[]binding) {
+	service := obj.Path()
+
+	for _, fn := range obj.Functions {
+		b := binding{
+			Name:       identifier(service) + identifier(fn.Name),
+			Service:    service,
+			Method:     fn.Name,
+			ReturnType: goType(fn.Type),
+			Returns:    fn.Type,
+		}
+
+		for _, arg := range fn.Arguments {
+			f := field{
+				Name:     identifier(arg.Name),
+				JSONName: arg.Name,
+				Type:     goType(arg.Type),
+				DataType: arg.Type,
+				Optional: !arg.Attributes.Mandatory,
+			}
+
+			if arg.Attributes.In {
+				b.In = append(b.In, f)
+			}
+
+			if arg.Attributes.Out {
+				f.Optional = false
+				b.Out = append(b.Out, f)
+			}
+		}
+
+		*bindings = append(*bindings, b)
+	}
+
+	for i := range obj.Children {
+		collect(&obj.Children[i], bindings)
+	}
+}
+
+// goType returns the Go type matching a datamodel type.
+func goType(t string) string {
+	switch t {
+	case "bool":
+		return "bool"
+	case "string", "csv_string", "ssv_string", "datetime":
+		return "string"
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return t
+	case "double":
+		return "float64"
+	case "list":
+		return "[]any"
+	case "htable", "object":
+		return "map[string]any"
+	default:
+		return "any"
+	}
+}
+
+// identifier returns an exported Go identifier for a datamodel name, e.g.
+// "NeMo.Intf.data" becomes "NeMoIntfData".
+func identifier(name string) string {
+	var b strings.Builder
+
+	upper := true
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteRune('X')
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,111 @@
+// Command livebox-gen generates typed Go bindings for the functions of the
+// Livebox datamodel. The description of the objects is read from a schema
+// file (the JSON-encoded output of Client.Introspect, or a recorded fixture),
+// or retrieved from a Livebox when -object is set.
+//
+// With -format openapi, an OpenAPI document describing the same functions is
+// generated instead, so that clients can be generated for other languages.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+func main() {
+	var (
+		schema  = flag.String("schema", "", "Path to a JSON-encoded schema file")
+		object  = flag.String("object", "", "Object to introspect on the Livebox instead of reading a schema file")
+		depth   = flag.Int("depth", 0, "Depth of the introspection, -1 for all descendants")
+		dump    = flag.String("dump", "", "Optional path where the introspected schema is written")
+		pkg     = flag.String("package", "bindings", "Package name of the generated code")
+		format  = flag.String("format", "go", "Output format: go or openapi")
+		outFile = flag.String("out", "", "Output file, defaults to stdout")
+	)
+	flag.Parse()
+
+	obj, err := loadSchema(*schema, *object, *depth)
+	if err != nil {
+		log.Fatalf("failed to load schema: %s", err)
+	}
+
+	if *dump != "" {
+		b, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to marshal schema: %s", err)
+		}
+
+		if err := os.WriteFile(*dump, b, 0o644); err != nil {
+			log.Fatalf("failed to write schema: %s", err)
+		}
+	}
+
+	var src []byte
+
+	switch *format {
+	case "go":
+		src, err = generate(*pkg, obj)
+	case "openapi":
+		src, err = generateOpenAPI(title(obj), obj)
+	default:
+		log.Fatalf("unknown format %q, expected go or openapi", *format)
+	}
+
+	if err != nil {
+		log.Fatalf("failed to generate bindings: %s", err)
+	}
+
+	if *outFile == "" {
+		_, _ = os.Stdout.Write(src)
+		return
+	}
+
+	if err := os.WriteFile(*outFile, src, 0o644); err != nil {
+		log.Fatalf("failed to write bindings: %s", err)
+	}
+}
+
+// loadSchema reads the schema from a file, or introspects the object on the
+// Livebox if object is not empty.
+func loadSchema(schema, object string, depth int) (*response.Object, error) {
+	if object != "" {
+		client, err := livebox.NewClient(os.Getenv("ADMIN_PASSWORD"))
+		if err != nil {
+			return nil, err
+		}
+
+		return client.Introspect(context.Background(), object, depth)
+	}
+
+	if schema == "" {
+		return nil, errors.New("-schema or -object is required")
+	}
+
+	b, err := os.ReadFile(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj response.Object
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+// title returns the title of the OpenAPI document of an object.
+func title(obj *response.Object) string {
+	if path := obj.Path(); path != "" {
+		return "Livebox " + path
+	}
+
+	return "Livebox datamodel"
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// openAPIVersion is the version of the OpenAPI specification of the generated
+// documents.
+const openAPIVersion = "3.0.3"
+
+// Content type of the requests sent to the sysbus endpoint.
+const sysbusContentType = "application/x-sah-ws-4-call+json"
+
+// openAPIDescription describes how the functions are called, it is the
+// description of the generated documents.
+const openAPIDescription = `Functions of the Livebox datamodel. Each function is called by sending its
+input arguments to the sysbus endpoint of the object, the session is
+created by a login request and identified by the X-Context header.
+
+The same function can be called by sending {"service": "<object>",
+"method": "<function>", "parameters": {...}} to the /ws endpoint.`
+
+// openAPI is an OpenAPI document.
+type openAPI struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       openAPIInfo                      `json:"info"`
+	Servers    []openAPIServer                  `json:"servers"`
+	Security   []map[string][]string            `json:"security"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components openAPIComponents                `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*schema        `json:"schemas"`
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// operation is the call of a function.
+type operation struct {
+	OperationID string            `json:"operationId"`
+	Summary     string            `json:"summary"`
+	Tags        []string          `json:"tags"`
+	RequestBody *requestBody      `json:"requestBody"`
+	Responses   map[string]*reply `json:"responses"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type reply struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+// schema is a JSON schema, as supported by OpenAPI 3.0.
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Minimum              *int64             `json:"minimum,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+}
+
+// generateOpenAPI returns an OpenAPI document describing the functions of the
+// object and its children.
+func generateOpenAPI(title string, obj *response.Object) ([]byte, error) {
+	doc := openAPI{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:       title,
+			Description: openAPIDescription,
+			Version:     "1",
+		},
+		Servers: []openAPIServer{{
+			URL:         "http://192.168.1.1",
+			Description: "Default address of the Livebox",
+		}},
+		Security: []map[string][]string{{"context": {}}},
+		Paths:    map[string]map[string]*operation{},
+		Components: openAPIComponents{
+			Schemas: map[string]*schema{
+				"Errors": errorsSchema(),
+			},
+			SecuritySchemes: map[string]securityScheme{
+				"context": {
+					Type:        "apiKey",
+					In:          "header",
+					Name:        "X-Context",
+					Description: "Context ID returned in the data of the login response.",
+				},
+			},
+		},
+	}
+
+	for _, b := range sortedBindings(obj) {
+		doc.Components.Schemas[b.Name+"Params"] = objectSchema(b.In)
+		doc.Components.Schemas[b.Name+"Data"] = objectSchema(b.Out)
+
+		path := "/sysbus/" + strings.ReplaceAll(b.Service, ".", "/") + ":" + b.Method
+
+		doc.Paths[path] = map[string]*operation{
+			"post": {
+				OperationID: b.Name,
+				Summary:     fmt.Sprintf("Calls %s:%s.", b.Service, b.Method),
+				Tags:        []string{b.Service},
+				RequestBody: &requestBody{
+					Required: true,
+					Content: map[string]mediaType{
+						sysbusContentType: {Schema: &schema{
+							Type: "object",
+							Properties: map[string]*schema{
+								"parameters": {Ref: "#/components/schemas/" + b.Name + "Params"},
+							},
+							Required: []string{"parameters"},
+						}},
+					},
+				},
+				Responses: map[string]*reply{
+					"200": {
+						Description: "Result of the function, or the errors that occurred.",
+						Content: map[string]mediaType{
+							"application/json": {Schema: &schema{
+								Type: "object",
+								Properties: map[string]*schema{
+									"status": dataSchema(b.Returns),
+									"data":   {Ref: "#/components/schemas/" + b.Name + "Data"},
+									"errors": {Ref: "#/components/schemas/Errors"},
+								},
+							}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// objectSchema returns the schema of an object made of fields.
+func objectSchema(fields []field) *schema {
+	s := &schema{Type: "object", Properties: map[string]*schema{}}
+
+	for _, f := range fields {
+		s.Properties[f.JSONName] = dataSchema(f.DataType)
+
+		if !f.Optional {
+			s.Required = append(s.Required, f.JSONName)
+		}
+	}
+
+	return s
+}
+
+// errorsSchema returns the schema of the errors returned by the Livebox
+// instead of the result of a function.
+func errorsSchema() *schema {
+	return &schema{
+		Type: "array",
+		Items: &schema{
+			Type: "object",
+			Properties: map[string]*schema{
+				"error":       {Type: "integer", Format: "int64"},
+				"description": {Type: "string"},
+				"info":        {Type: "string"},
+			},
+		},
+	}
+}
+
+// dataSchema returns the schema matching a datamodel type.
+func dataSchema(t string) *schema {
+	var zero int64
+
+	switch t {
+	case "bool":
+		return &schema{Type: "boolean"}
+	case "string":
+		return &schema{Type: "string"}
+	case "csv_string":
+		return &schema{Type: "string", Description: "Comma-separated values."}
+	case "ssv_string":
+		return &schema{Type: "string", Description: "Space-separated values."}
+	case "datetime":
+		return &schema{Type: "string", Format: "date-time"}
+	case "int8", "int16", "int32":
+		return &schema{Type: "integer", Format: "int32"}
+	case "int64":
+		return &schema{Type: "integer", Format: "int64"}
+	case "uint8", "uint16":
+		return &schema{Type: "integer", Format: "int32", Minimum: &zero}
+	case "uint32", "uint64":
+		return &schema{Type: "integer", Format: "int64", Minimum: &zero}
+	case "double":
+		return &schema{Type: "number", Format: "double"}
+	case "list":
+		return &schema{Type: "array", Items: &schema{}}
+	case "htable", "object":
+		return &schema{Type: "object", AdditionalProperties: true}
+	default:
+		return &schema{}
+	}
+}
@@ -0,0 +1,40 @@
+package livebox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// ErrInvalidConfig is returned by RestoreConfig when the given configuration
+// is not a configuration exported by ExportConfig.
+var ErrInvalidConfig = errors.New("invalid livebox configuration")
+
+// ExportConfig returns a backup of the Livebox configuration. The returned
+// data is opaque, it can be stored and given back to RestoreConfig.
+func (c *Client) ExportConfig(ctx context.Context) ([]byte, error) {
+	var out response.Status[json.RawMessage]
+	if err := c.Request(ctx, sah.NMCNetworkConfig.ExportConfig.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	if len(out.Status) == 0 || string(out.Status) == "null" {
+		return nil, ErrUnsuccessful
+	}
+
+	return out.Status, nil
+}
+
+// RestoreConfig restores a configuration previously exported by ExportConfig.
+// The Livebox may reboot after the configuration is restored.
+func (c *Client) RestoreConfig(ctx context.Context, data []byte) error {
+	if !json.Valid(data) {
+		return ErrInvalidConfig
+	}
+
+	return c.requestBool(ctx, sah.NMCNetworkConfig.ImportConfig.Request(request.Parameters{"config": json.RawMessage(data)}))
+}
@@ -0,0 +1,70 @@
+package livebox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// DefaultInterfaces are the interfaces for which GetInterfaceCounters returns
+// counters when no interface is specified: the four Ethernet ports, the Wi-Fi
+// radios and the WAN interface.
+var DefaultInterfaces = []string{"eth0", "eth1", "eth2", "eth3", "wl0", "wl1", "veip0"}
+
+// GetInterfaceCounters returns the traffic counters of the given interfaces.
+// If no interface is given, DefaultInterfaces are used.
+func (c *Client) GetInterfaceCounters(ctx context.Context, intfs ...string) ([]response.InterfaceCounters, error) {
+	if len(intfs) == 0 {
+		intfs = DefaultInterfaces
+	}
+
+	counters := make([]response.InterfaceCounters, 0, len(intfs))
+
+	for _, intf := range intfs {
+		var out response.Status[response.InterfaceCounters]
+		if err := c.Request(ctx, request.New("NeMo.Intf."+intf, "getNetDevStats", nil), &out); err != nil {
+			return nil, fmt.Errorf("failed to get counters of interface %s: %w", intf, err)
+		}
+
+		out.Status.Interface = intf
+		counters = append(counters, out.Status)
+	}
+
+	return counters, nil
+}
+
+// GetDeviceCounters returns the latest traffic counters of the devices of the
+// home network, recorded periodically by the HomeLan service. Devices are
+// sorted by MAC address.
+func (c *Client) GetDeviceCounters(ctx context.Context) ([]response.DeviceCounters, error) {
+	var out response.Status[map[string]struct {
+		Traffic []response.DeviceCounters `json:"Traffic"`
+	}]
+	if err := c.Request(ctx, sah.HomeLan.GetDevicesResults.Request(request.Parameters{
+		"Seconds":          0,
+		"NumberOfReadings": 1,
+	}), &out); err != nil {
+		return nil, err
+	}
+
+	counters := make([]response.DeviceCounters, 0, len(out.Status))
+	for mac, results := range out.Status {
+		if len(results.Traffic) == 0 {
+			continue
+		}
+
+		// The readings are sorted by time.
+		latest := results.Traffic[len(results.Traffic)-1]
+		latest.MACAddress = strings.ToUpper(mac)
+		counters = append(counters, latest)
+	}
+
+	sort.Slice(counters, func(i, j int) bool { return counters[i].MACAddress < counters[j].MACAddress })
+
+	return counters, nil
+}
@@ -0,0 +1,52 @@
+package livebox
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// CredentialsProvider provides the username and password used to
+// authenticate. It is called before each authentication, so that credentials
+// can be rotated without recreating the client.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (username, password string, err error)
+}
+
+// CredentialsProviderFunc is a function that implements CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (username, password string, err error)
+
+// Credentials implements CredentialsProvider.
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (string, string, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a CredentialsProvider that always returns the
+// given username and password.
+func StaticCredentials(username, password string) CredentialsProvider {
+	return CredentialsProviderFunc(func(context.Context) (string, string, error) {
+		return username, password, nil
+	})
+}
+
+// FileCredentials returns a CredentialsProvider that reads the password from
+// a file on each authentication. Leading and trailing whitespace is removed.
+func FileCredentials(username, path string) CredentialsProvider {
+	return CredentialsProviderFunc(func(context.Context) (string, string, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", err
+		}
+
+		return username, strings.TrimSpace(string(b)), nil
+	})
+}
+
+// WithCredentialsProvider sets the provider of the credentials used to
+// authenticate. When set, the password given to NewClient and the username
+// set with WithUsername are ignored.
+func WithCredentialsProvider(provider CredentialsProvider) Opt {
+	return func(c *clientOpts) {
+		c.credentials = provider
+	}
+}
@@ -0,0 +1,81 @@
+package livebox
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+
+	"github.com/Tomy2e/livebox-api-client/internal/scrub"
+)
+
+// Maximum size of a dumped body, bodies are truncated beyond that size.
+const debugBodyLimit = 4 << 10
+
+// WithDebugTransport dumps the headers and bodies of the HTTP requests sent to
+// the Livebox and of their responses to w, to troubleshoot undocumented
+// endpoints. Credentials and session tokens are scrubbed from the dumps, and
+// bodies larger than 4 KiB are truncated.
+func WithDebugTransport(w io.Writer) Opt {
+	return func(c *clientOpts) {
+		c.debugWriter = w
+	}
+}
+
+// debugTransport dumps requests and responses.
+type debugTransport struct {
+	next http.RoundTripper
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// withDebugTransport returns a copy of the HTTP client that dumps requests and
+// responses to w.
+func withDebugTransport(client *http.Client, w io.Writer) *http.Client {
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	c := *client
+	c.Transport = &debugTransport{next: next, w: w}
+
+	return &c
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+		t.write(">>>", dump)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.write("!!!", []byte(err.Error()))
+		return nil, err
+	}
+
+	if dump, err := httputil.DumpResponse(res, true); err == nil {
+		t.write("<<<", dump)
+	}
+
+	return res, nil
+}
+
+// write writes a scrubbed and truncated dump.
+func (t *debugTransport) write(prefix string, dump []byte) {
+	dump = scrub.Secrets.Text(dump)
+
+	if i := bytes.Index(dump, []byte("\r\n\r\n")); i >= 0 {
+		if body := dump[i+4:]; len(body) > debugBodyLimit {
+			dump = append(dump[:i+4+debugBodyLimit:i+4+debugBodyLimit], fmt.Sprintf("... (%d bytes truncated)", len(body)-debugBodyLimit)...)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fmt.Fprintf(t.w, "%s\n%s\n\n", prefix, bytes.TrimSpace(dump))
+}
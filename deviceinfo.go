@@ -0,0 +1,44 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetDeviceInfo returns general information about the Livebox, including its
+// uptime and its number of reboots.
+func (c *Client) GetDeviceInfo(ctx context.Context) (*response.DeviceInfo, error) {
+	var out response.Status[response.DeviceInfo]
+	if err := c.Request(ctx, sah.DeviceInfo.Get.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Status, nil
+}
+
+// GetRebootHistory returns the reboots stored by the Livebox, with their
+// reason.
+func (c *Client) GetRebootHistory(ctx context.Context) ([]response.Reboot, error) {
+	var out response.Status[[]response.Reboot]
+	if err := c.Request(ctx, sah.NMCReboot.GetHistory.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return out.Status, nil
+}
+
+// Reboot reboots the Livebox. The Livebox stops responding shortly after the
+// call returns, and it takes a few minutes before it is reachable again.
+func (c *Client) Reboot(ctx context.Context) error {
+	return c.requestBool(ctx, sah.NMC.Reboot.Request(request.Parameters{"reason": "GUI_Reboot"}))
+}
+
+// CheckForUpgrade asks the Livebox to check whether a new firmware is
+// available. If there is one, the Livebox downloads and installs it on its own,
+// then reboots. See GetDeviceInfo for the version of the current firmware.
+func (c *Client) CheckForUpgrade(ctx context.Context) error {
+	return c.requestBool(ctx, sah.NMC.CheckForUpgrades.Request(nil))
+}
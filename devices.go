@@ -0,0 +1,100 @@
+package livebox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// Devices gives access to the devices known by the Livebox.
+type Devices struct {
+	client *Client
+}
+
+// Devices returns a handle to manage the devices known by the Livebox.
+func (c *Client) Devices() *Devices {
+	return &Devices{client: c}
+}
+
+// List returns the physical devices known by the Livebox, whether they are
+// connected or not.
+func (d *Devices) List(ctx context.Context) ([]response.Device, error) {
+	return d.client.getDevices(ctx, "physical")
+}
+
+// WakeOnLAN asks the Livebox to send a Wake-on-LAN magic packet to the device
+// with the given MAC address.
+func (d *Devices) WakeOnLAN(ctx context.Context, mac string) error {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return err
+	}
+
+	return d.client.requestBool(ctx, sah.WOL.SendWakeOnLan.Request(request.Parameters{
+		"hostID":    key,
+		"broadcast": true,
+	}))
+}
+
+// SetName sets the friendly name of the device with the given MAC address.
+func (d *Devices) SetName(ctx context.Context, mac, name string) error {
+	return d.set(ctx, mac, "setName", request.Parameters{"name": name})
+}
+
+// SetType sets the type of the device with the given MAC address. The type
+// selects the icon displayed in the web interface (e.g. "Computer", "Laptop",
+// "Smartphone", "Printer").
+func (d *Devices) SetType(ctx context.Context, mac, deviceType string) error {
+	return d.set(ctx, mac, "setType", request.Parameters{"type": deviceType})
+}
+
+// Delete removes the device with the given MAC address from the devices known
+// by the Livebox. A device that is still connected will reappear as soon as
+// it is discovered again.
+func (d *Devices) Delete(ctx context.Context, mac string) error {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return err
+	}
+
+	return d.client.requestBool(ctx, sah.Devices.DestroyDevice.Request(request.Parameters{"key": key}))
+}
+
+// set calls a setter method on the device with the given MAC address, as the
+// web interface does.
+func (d *Devices) set(ctx context.Context, mac, method string, params request.Parameters) error {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return err
+	}
+
+	params["source"] = "webui"
+
+	return d.client.requestBool(ctx, request.New("Devices.Device."+key, method, params))
+}
+
+// getDevices returns the devices matching the given expression.
+func (c *Client) getDevices(ctx context.Context, expression string) ([]response.Device, error) {
+	var out response.Status[[]response.Device]
+	if err := c.Request(ctx, sah.Devices.Get.Request(request.Parameters{"expression": expression}), &out); err != nil {
+		return nil, err
+	}
+
+	return out.Status, nil
+}
+
+// deviceKey returns the key of a device from its MAC address. The Livebox
+// uses upper-case, colon-separated MAC addresses as keys.
+func deviceKey(mac string) (string, error) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return "", fmt.Errorf("invalid MAC address: %w", err)
+	}
+
+	return strings.ToUpper(hw.String()), nil
+}
@@ -0,0 +1,81 @@
+package livebox
+
+import (
+	"context"
+	"net/netip"
+	"sort"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetDHCPLeases returns the leases of the DHCP server, sorted by IP address.
+func (c *Client) GetDHCPLeases(ctx context.Context) ([]response.DHCPLease, error) {
+	var out response.Status[map[string]response.DHCPLease]
+	if err := c.Request(ctx, sah.DHCPv4ServerPoolDefault.GetLeases.Request(request.Parameters{"rule": ""}), &out); err != nil {
+		return nil, err
+	}
+
+	leases := make([]response.DHCPLease, 0, len(out.Status))
+	for _, lease := range out.Status {
+		leases = append(leases, lease)
+	}
+
+	sort.Slice(leases, func(i, j int) bool { return lessIP(leases[i].IPAddress, leases[j].IPAddress) })
+
+	return leases, nil
+}
+
+// GetStaticLeases returns the static leases of the DHCP server.
+func (c *Client) GetStaticLeases(ctx context.Context) ([]response.StaticLease, error) {
+	var out response.Status[[]response.StaticLease]
+	if err := c.Request(ctx, sah.DHCPv4ServerPoolDefault.GetStaticLeases.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return out.Status, nil
+}
+
+// AddStaticLease reserves an IP address for the device with the given MAC
+// address. The address must be part of the LAN, see GetLANConfig.
+func (c *Client) AddStaticLease(ctx context.Context, mac, ip string) error {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return err
+	}
+
+	return c.requestBool(ctx, sah.DHCPv4ServerPoolDefault.AddStaticLease.Request(request.Parameters{
+		"MACAddress": key,
+		"IPAddress":  ip,
+	}))
+}
+
+// DeleteStaticLease removes the static lease of the device with the given MAC
+// address.
+func (c *Client) DeleteStaticLease(ctx context.Context, mac string) error {
+	key, err := deviceKey(mac)
+	if err != nil {
+		return err
+	}
+
+	return c.requestBool(ctx, sah.DHCPv4ServerPoolDefault.DeleteStaticLease.Request(request.Parameters{
+		"MACAddress": key,
+	}))
+}
+
+// lessIP compares two IP addresses numerically, invalid addresses are compared
+// as strings after the valid ones.
+func lessIP(a, b string) bool {
+	ipA, errA := netip.ParseAddr(a)
+	ipB, errB := netip.ParseAddr(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return ipA.Less(ipB)
+	case errA == nil || errB == nil:
+		return errA == nil
+	default:
+		return a < b
+	}
+}
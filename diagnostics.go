@@ -0,0 +1,61 @@
+package livebox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// Ping asks the Livebox to send count ICMP echo requests to host and returns
+// the result. The call blocks until the diagnostic is complete.
+func (c *Client) Ping(ctx context.Context, host string, count int) (*response.PingResult, error) {
+	var out response.Status[response.PingResult]
+	if err := c.Request(ctx, sah.IPPingDiagnostics.ExecDiagnostic.Request(request.Parameters{
+		"host":        host,
+		"repetitions": count,
+	}), &out); err != nil {
+		return nil, err
+	}
+
+	if out.Status.DiagnosticsState != response.DiagnosticsStateComplete {
+		return nil, fmt.Errorf("%w: ping diagnostic state is %q", ErrUnsuccessful, out.Status.DiagnosticsState)
+	}
+
+	return &out.Status, nil
+}
+
+// Traceroute asks the Livebox to run a traceroute to host and returns the
+// result. The call blocks until the diagnostic is complete.
+func (c *Client) Traceroute(ctx context.Context, host string) (*response.TracerouteResult, error) {
+	var out response.Status[response.TracerouteResult]
+	if err := c.Request(ctx, sah.TraceRouteDiagnostics.ExecDiagnostic.Request(request.Parameters{
+		"host": host,
+	}), &out); err != nil {
+		return nil, err
+	}
+
+	if out.Status.DiagnosticsState != response.DiagnosticsStateComplete {
+		return nil, fmt.Errorf("%w: traceroute diagnostic state is %q", ErrUnsuccessful, out.Status.DiagnosticsState)
+	}
+
+	return &out.Status, nil
+}
+
+// GetWANLatency measures the latency between the Livebox and the first hop of
+// the ISP network (the WAN gateway) by sending count pings from the Livebox.
+// It can be called periodically to trend the quality of the connection.
+func (c *Client) GetWANLatency(ctx context.Context, count int) (*response.PingResult, error) {
+	status, err := c.GetWANStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.RemoteGateway == "" {
+		return nil, fmt.Errorf("%w: WAN gateway is unknown", ErrUnsuccessful)
+	}
+
+	return c.Ping(ctx, status.RemoteGateway, count)
+}
@@ -0,0 +1,117 @@
+package livebox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/internal/client"
+)
+
+// ErrLiveboxNotFound is returned by Discover when no Livebox was found.
+var ErrLiveboxNotFound = errors.New("no livebox found")
+
+// Timeout of the probe sent to each candidate address.
+const discoverProbeTimeout = 2 * time.Second
+
+// Hostname of the Livebox, resolved by its DNS server.
+const liveboxHostname = "livebox.home"
+
+// Discover finds the address of the Livebox. The default gateways of the host
+// are probed first, then the Livebox hostname and DefaultAddress. An address
+// is accepted if its API endpoint responds like a Livebox does. The returned
+// address can be given to WithAddress.
+func Discover(ctx context.Context) (string, error) {
+	candidates := []string{}
+
+	gateways, err := defaultGateways()
+	if err == nil {
+		for _, gw := range gateways {
+			candidates = append(candidates, "http://"+gw.String())
+		}
+	}
+
+	candidates = append(candidates, "http://"+liveboxHostname, DefaultAddress)
+
+	seen := map[string]bool{}
+
+	for _, address := range candidates {
+		if seen[address] {
+			continue
+		}
+
+		seen[address] = true
+
+		if probe(ctx, http.DefaultClient, address) {
+			return address, nil
+		}
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+	}
+
+	return "", ErrLiveboxNotFound
+}
+
+// probe returns true if the API endpoint at the given address responds like a
+// Livebox: with the content type of the SAH API, or with a response of the SAH
+// API, that is errors with a numeric code and a description, or the
+// information of a device made by a manufacturer of Liveboxes.
+func probe(ctx context.Context, httpClient *http.Client, address string) bool {
+	ctx, cancel := context.WithTimeout(ctx, discoverProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		strings.TrimSuffix(address, "/")+"/ws",
+		bytes.NewReader([]byte(`{"service":"sah.Device.Information","method":"get","parameters":{}}`)),
+	)
+	if err != nil {
+		return false
+	}
+
+	req.Header.Set("Content-Type", string(client.ContentTypeWS))
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+
+	if strings.Contains(res.Header.Get("Content-Type"), "x-sah-ws-") {
+		return true
+	}
+
+	var body struct {
+		Status *struct {
+			Manufacturer string `json:"Manufacturer"`
+			ModelName    string `json:"ModelName"`
+		} `json:"status"`
+		Errors []struct {
+			Error       *int    `json:"error"`
+			Description *string `json:"description"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<16)).Decode(&body); err != nil {
+		return false
+	}
+
+	if len(body.Errors) > 0 {
+		for _, e := range body.Errors {
+			if e.Error == nil || e.Description == nil {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return body.Status != nil && liveboxNameRe.MatchString(body.Status.Manufacturer+" "+body.Status.ModelName)
+}
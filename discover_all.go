@@ -0,0 +1,128 @@
+package livebox
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Sources of discovered devices.
+const (
+	DiscoverySourceGateway = "gateway"
+	DiscoverySourceSSDP    = "ssdp"
+	DiscoverySourceMDNS    = "mdns"
+)
+
+// Time spent waiting for SSDP and mDNS responses.
+const discoverListenTimeout = 3 * time.Second
+
+// Matches the names advertised by Liveboxes and Orange Wi-Fi extenders.
+var liveboxNameRe = regexp.MustCompile(`(?i)livebox|orange|sagemcom|arcadyan|wifi extender`)
+
+// DiscoveredDevice is a Livebox or an extender found on the LAN.
+type DiscoveredDevice struct {
+	// Address of the device, it can be given to WithAddress.
+	Address string
+	// Model advertised by the device, if any.
+	Model string
+	// Firmware version advertised by the device, if any.
+	Firmware string
+	// How the device was found: DiscoverySourceGateway, DiscoverySourceSSDP
+	// or DiscoverySourceMDNS.
+	Source string
+}
+
+// DiscoverAll finds the Liveboxes and Wi-Fi extenders on the LAN, using the
+// default gateway probe of Discover, SSDP and mDNS. SSDP and mDNS responses
+// also give the model and firmware of the devices. Devices are returned
+// sorted by address, each address is only returned once.
+func DiscoverAll(ctx context.Context) ([]DiscoveredDevice, error) {
+	ctx, cancel := context.WithTimeout(ctx, discoverListenTimeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		devices = map[string]DiscoveredDevice{}
+		errs    []error
+	)
+
+	add := func(found []DiscoveredDevice, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		for _, d := range found {
+			host := hostOf(d.Address)
+			existing, ok := devices[host]
+
+			// Keep the most detailed description of the device.
+			if !ok || (existing.Model == "" && d.Model != "") {
+				devices[host] = d
+			}
+		}
+	}
+
+	for _, discover := range []func(context.Context) ([]DiscoveredDevice, error){
+		discoverGateway,
+		discoverSSDP,
+		discoverMDNS,
+	} {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			add(discover(ctx))
+		}()
+	}
+
+	wg.Wait()
+
+	if len(devices) == 0 {
+		if len(errs) > 0 {
+			return nil, errs[0]
+		}
+
+		return nil, ErrLiveboxNotFound
+	}
+
+	out := make([]DiscoveredDevice, 0, len(devices))
+	for _, d := range devices {
+		out = append(out, d)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Address < out[j].Address })
+
+	return out, nil
+}
+
+// discoverGateway returns the Livebox found by Discover.
+func discoverGateway(ctx context.Context) ([]DiscoveredDevice, error) {
+	address, err := Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []DiscoveredDevice{{Address: address, Source: DiscoverySourceGateway}}, nil
+}
+
+// hostOf returns the host of an address, without port.
+func hostOf(address string) string {
+	u, err := url.Parse(address)
+	if err != nil || u.Host == "" {
+		return address
+	}
+
+	if host, _, err := net.SplitHostPort(u.Host); err == nil {
+		return host
+	}
+
+	return u.Host
+}
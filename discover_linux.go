@@ -0,0 +1,46 @@
+package livebox
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"strings"
+)
+
+// defaultGateways returns the IPv4 default gateways of the host, read from
+// the kernel routing table.
+func defaultGateways() ([]net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var gateways []net.IP
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Columns: Iface Destination Gateway Flags ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+
+		b, err := hex.DecodeString(fields[2])
+		if err != nil || len(b) != net.IPv4len {
+			continue
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		// Addresses are stored in host byte order.
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(b))
+
+		if !ip.IsUnspecified() {
+			gateways = append(gateways, ip)
+		}
+	}
+
+	return gateways, scanner.Err()
+}
@@ -0,0 +1,107 @@
+package livebox
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// mDNS multicast address.
+const mdnsAddress = "224.0.0.251:5353"
+
+// Service type queried with mDNS, Liveboxes advertise their web interface.
+const mdnsService = "_http._tcp.local."
+
+// discoverMDNS sends an mDNS query and returns the Livebox devices that
+// answered until ctx is done.
+func discoverMDNS(ctx context.Context) ([]DiscoveredDevice, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	dst, err := net.ResolveUDPAddr("udp4", mdnsAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := newMDNSQuery(mdnsService)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.WriteTo(query, dst); err != nil {
+		return nil, err
+	}
+
+	var devices []DiscoveredDevice
+
+	readPackets(ctx, conn, func(b []byte) {
+		if d, ok := parseMDNSResponse(b); ok {
+			devices = append(devices, d)
+		}
+	})
+
+	return devices, nil
+}
+
+func newMDNSQuery(service string) ([]byte, error) {
+	name, err := dnsmessage.NewName(service)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{
+			Name:  name,
+			Type:  dnsmessage.TypePTR,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+
+	return msg.Pack()
+}
+
+// parseMDNSResponse returns the device described by an mDNS response, if it is
+// a Livebox. The model and firmware are read from the "model" and "fw" or
+// "version" TXT entries.
+func parseMDNSResponse(b []byte) (DiscoveredDevice, bool) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(b); err != nil || !msg.Response {
+		return DiscoveredDevice{}, false
+	}
+
+	var (
+		d     = DiscoveredDevice{Source: DiscoverySourceMDNS}
+		names []string
+	)
+
+	for _, rr := range append(msg.Answers, msg.Additionals...) {
+		switch body := rr.Body.(type) {
+		case *dnsmessage.PTRResource:
+			names = append(names, body.PTR.String())
+		case *dnsmessage.AResource:
+			d.Address = "http://" + net.IP(body.A[:]).String()
+		case *dnsmessage.TXTResource:
+			for _, txt := range body.TXT {
+				key, value, _ := strings.Cut(txt, "=")
+
+				switch strings.ToLower(key) {
+				case "model":
+					d.Model = value
+				case "fw", "version":
+					d.Firmware = value
+				}
+			}
+		}
+	}
+
+	if d.Address == "" || !liveboxNameRe.MatchString(strings.Join(names, " ")+" "+d.Model) {
+		return DiscoveredDevice{}, false
+	}
+
+	return d, true
+}
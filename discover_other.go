@@ -0,0 +1,14 @@
+//go:build !linux
+
+package livebox
+
+import (
+	"errors"
+	"net"
+)
+
+// defaultGateways is not supported on this platform, Discover falls back to
+// the well-known addresses.
+func defaultGateways() ([]net.IP, error) {
+	return nil, errors.New("default gateway lookup is not supported on this platform")
+}
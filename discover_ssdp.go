@@ -0,0 +1,178 @@
+package livebox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SSDP multicast address.
+const ssdpAddress = "239.255.255.250:1900"
+
+// Search target of the SSDP request, Liveboxes advertise themselves as
+// internet gateway devices.
+const ssdpSearchTarget = "upnp:rootdevice"
+
+// upnpDescription is the UPnP device description of a device.
+type upnpDescription struct {
+	Device struct {
+		FriendlyName    string `xml:"friendlyName"`
+		Manufacturer    string `xml:"manufacturer"`
+		ModelName       string `xml:"modelName"`
+		ModelNumber     string `xml:"modelNumber"`
+		SoftwareVersion string `xml:"softwareVersion"`
+	} `xml:"device"`
+}
+
+// discoverSSDP sends an SSDP search request and returns the Livebox devices
+// that answered until ctx is done.
+func discoverSSDP(ctx context.Context) ([]DiscoveredDevice, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	dst, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	msearch := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddress + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n" +
+		"ST: " + ssdpSearchTarget + "\r\n\r\n"
+
+	if _, err := conn.WriteTo([]byte(msearch), dst); err != nil {
+		return nil, err
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		locations = map[string]bool{}
+		devices   []DiscoveredDevice
+	)
+
+	// Descriptions are downloaded while listening, with the same context.
+	readPackets(ctx, conn, func(b []byte) {
+		res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), nil)
+		if err != nil {
+			return
+		}
+
+		location := res.Header.Get("Location")
+		if location == "" || locations[location] {
+			return
+		}
+
+		locations[location] = true
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if d, ok := describeSSDPDevice(ctx, location); ok {
+				mu.Lock()
+				devices = append(devices, d)
+				mu.Unlock()
+			}
+		}()
+	})
+
+	wg.Wait()
+
+	return devices, nil
+}
+
+// describeSSDPDevice returns the device whose UPnP description is at
+// location, if it is a Livebox.
+func describeSSDPDevice(ctx context.Context, location string) (DiscoveredDevice, bool) {
+	desc, err := fetchUPnPDescription(ctx, location)
+	if err != nil {
+		return DiscoveredDevice{}, false
+	}
+
+	d := desc.Device
+	if !liveboxNameRe.MatchString(d.FriendlyName + " " + d.Manufacturer + " " + d.ModelName) {
+		return DiscoveredDevice{}, false
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return DiscoveredDevice{}, false
+	}
+
+	firmware := d.SoftwareVersion
+	if firmware == "" {
+		firmware = d.ModelNumber
+	}
+
+	return DiscoveredDevice{
+		Address:  "http://" + u.Hostname(),
+		Model:    d.ModelName,
+		Firmware: firmware,
+		Source:   DiscoverySourceSSDP,
+	}, true
+}
+
+// fetchUPnPDescription downloads the UPnP device description at location.
+func fetchUPnPDescription(ctx context.Context, location string) (*upnpDescription, error) {
+	ctx, cancel := context.WithTimeout(ctx, discoverProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var desc upnpDescription
+	if err := xml.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&desc); err != nil {
+		return nil, err
+	}
+
+	return &desc, nil
+}
+
+// readPackets calls handle with the packets received on conn until ctx is
+// done. The packets must not be retained after handle returns.
+func readPackets(ctx context.Context, conn net.PacketConn, handle func(b []byte)) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(discoverListenTimeout)
+	}
+
+	_ = conn.SetReadDeadline(deadline)
+
+	// Unblock the read if ctx is canceled before the deadline.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+
+	buf := make([]byte, 65536)
+
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		handle(buf[:n])
+	}
+}
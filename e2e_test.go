@@ -0,0 +1,75 @@
+//go:build livebox_e2e
+
+package livebox_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+// TestE2E sends read-only requests to a real Livebox and checks that the
+// responses are decoded. It only runs with the livebox_e2e build tag:
+//
+//	ADMIN_PASSWORD=<password> go test -tags=livebox_e2e -run TestE2E -v .
+//
+// The LIVEBOX_ADDRESS and LIVEBOX_USERNAME environment variables can be set to
+// use a non-default address or username.
+func TestE2E(t *testing.T) {
+	password := os.Getenv("ADMIN_PASSWORD")
+	if password == "" {
+		t.Skip("ADMIN_PASSWORD is not set")
+	}
+
+	opts := []livebox.Opt{}
+	if address := os.Getenv("LIVEBOX_ADDRESS"); address != "" {
+		opts = append(opts, livebox.WithAddress(address))
+	}
+
+	if username := os.Getenv("LIVEBOX_USERNAME"); username != "" {
+		opts = append(opts, livebox.WithUsername(username))
+	}
+
+	c, err := livebox.NewClient(password, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := map[string]func(ctx context.Context) (any, error){
+		"GetDeviceInfo":      func(ctx context.Context) (any, error) { return c.GetDeviceInfo(ctx) },
+		"GetRebootHistory":   func(ctx context.Context) (any, error) { return c.GetRebootHistory(ctx) },
+		"GetWANStatus":       func(ctx context.Context) (any, error) { return c.GetWANStatus(ctx) },
+		"GetWANMode":         func(ctx context.Context) (any, error) { return c.GetWANMode(ctx) },
+		"GetLANConfig":       func(ctx context.Context) (any, error) { return c.GetLANConfig(ctx) },
+		"GetTopology":        func(ctx context.Context) (any, error) { return c.GetTopology(ctx) },
+		"GetInterfaceLayout": func(ctx context.Context) (any, error) { return c.GetInterfaceLayout(ctx) },
+		"GetDeviceCounters":  func(ctx context.Context) (any, error) { return c.GetDeviceCounters(ctx) },
+		"GetCallList":        func(ctx context.Context) (any, error) { return c.GetCallList(ctx) },
+		"GetVoiceTrunks":     func(ctx context.Context) (any, error) { return c.GetVoiceTrunks(ctx) },
+		"GetTVStatus":        func(ctx context.Context) (any, error) { return c.GetTVStatus(ctx) },
+		"GetIGMPConfig":      func(ctx context.Context) (any, error) { return c.GetIGMPConfig(ctx) },
+		"GetUsers":           func(ctx context.Context) (any, error) { return c.GetUsers(ctx) },
+		"GetRemoteAccess":    func(ctx context.Context) (any, error) { return c.GetRemoteAccess(ctx) },
+		"GetSambaConfig":     func(ctx context.Context) (any, error) { return c.GetSambaConfig(ctx) },
+		"GetDLNAConfig":      func(ctx context.Context) (any, error) { return c.GetDLNAConfig(ctx) },
+		"Blocked":            func(ctx context.Context) (any, error) { return c.Devices().Blocked(ctx) },
+		"Introspect":         func(ctx context.Context) (any, error) { return c.Introspect(ctx, "NMC", 0) },
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			out, err := call(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			t.Logf("%+v", out)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package livebox
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// WithEventDedup suppresses the events identical to an event received less
+// than window ago: same handler, reason and attributes. The Livebox often sends
+// bursts of identical events, especially when an event channel is created.
+// Events are not deduplicated if unset.
+func WithEventDedup(window time.Duration) Opt {
+	return func(c *clientOpts) {
+		c.eventDedup = window
+	}
+}
+
+// eventDeduper suppresses identical events received within a window.
+type eventDeduper struct {
+	window time.Duration
+
+	// mu guards the following fields.
+	mu sync.Mutex
+	// Time at which each event was last received.
+	seen      map[string]time.Time
+	lastPrune time.Time
+}
+
+// newEventDeduper returns an eventDeduper, or nil if window is not positive.
+func newEventDeduper(window time.Duration) *eventDeduper {
+	if window <= 0 {
+		return nil
+	}
+
+	return &eventDeduper{window: window, seen: map[string]time.Time{}}
+}
+
+// duplicate returns true if an identical event was received less than the
+// window ago. It is nil-safe: events are never duplicates if d is nil.
+func (d *eventDeduper) duplicate(ev *response.EventData) bool {
+	if d == nil {
+		return false
+	}
+
+	attributes, err := json.Marshal(ev.Object.Attributes)
+	if err != nil {
+		return false
+	}
+
+	key := ev.Handler + "\x00" + ev.Object.Reason + "\x00" + string(attributes)
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Remove expired events from time to time.
+	if now.Sub(d.lastPrune) > d.window {
+		for k, t := range d.seen {
+			if now.Sub(t) > d.window {
+				delete(d.seen, k)
+			}
+		}
+
+		d.lastPrune = now
+	}
+
+	// The window starts at the first delivered event.
+	if last, ok := d.seen[key]; ok && now.Sub(last) <= d.window {
+		return true
+	}
+
+	d.seen[key] = now
+
+	return false
+}
@@ -0,0 +1,44 @@
+package livebox
+
+// Objects whose events can be watched with Client.Events and Client.Subscribe.
+// They were observed on Livebox 4 to 7 firmwares, the events of their
+// children are also received.
+const (
+	// EventDevices is the object of the devices known by the Livebox. Events
+	// are sent when a device is added ("device_added"), removed
+	// ("device_deleted"), or when its parameters change ("changed"), such as
+	// its Active parameter when it connects or disconnects.
+	EventDevices = "Devices.Device"
+	// EventDeviceInfo is the object of the information of the Livebox, such as
+	// its firmware version and uptime.
+	EventDeviceInfo = "sah.Device.Information"
+	// EventNMC is the object of the network management of the Livebox: WAN
+	// connection state, public IP address and WAN mode.
+	EventNMC = "NMC"
+	// EventWiFi is the object of the Wi-Fi configuration, events are sent
+	// when a Wi-Fi network is enabled or disabled.
+	EventWiFi = "NMC.Wifi"
+	// EventInterfaces is the object of the network interfaces. Events are
+	// sent when the status or the addresses of an interface change.
+	EventInterfaces = "NeMo.Intf"
+	// EventWAN is the object of the WAN interface.
+	EventWAN = "NeMo.Intf.data"
+	// EventLAN is the object of the LAN bridge interface.
+	EventLAN = "NeMo.Intf.lan"
+	// EventVoice is the object of the telephony service, events are sent
+	// for incoming and outgoing calls.
+	EventVoice = "VoiceService.VoiceApplication"
+	// EventUsers is the object of the users of the Livebox, events are sent
+	// when a session is created or expires.
+	EventUsers = "UserManagement"
+	// EventTime is the object of the clock of the Livebox, events are sent
+	// when it is synchronized.
+	EventTime = "Time"
+)
+
+// Wildcard returns a pattern that matches the events of all the children of
+// object, but not the events of object itself. For instance,
+// Wildcard(EventDevices) matches the events of every device.
+func Wildcard(object string) string {
+	return object + ".*"
+}
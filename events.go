@@ -2,35 +2,144 @@ package livebox
 
 import (
 	"context"
-	"encoding/json"
-	"log/slog"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/Tomy2e/livebox-api-client/api/request"
 	"github.com/Tomy2e/livebox-api-client/api/response"
 	"github.com/Tomy2e/livebox-api-client/internal/client"
 )
 
-type events struct {
+// DefaultEventBufferSize is the default size of the buffer of the channels
+// returned by Events.
+const DefaultEventBufferSize = 128
+
+// OverflowPolicy defines what happens when an event is received while the
+// buffer of a listener is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the listener to receive the event. Other
+	// listeners do not receive events in the meantime.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest event of the buffer.
+	OverflowDropOldest
+	// OverflowDropNewest drops the received event.
+	OverflowDropNewest
+)
+
+// WithEventBuffer sets the size of the buffer of the channels returned by
+// Events. If not used, DefaultEventBufferSize is used.
+func WithEventBuffer(size int) Opt {
+	return func(c *clientOpts) {
+		c.eventBufferSize = size
+	}
+}
+
+// WithEventOverflow sets what happens when an event is received while the
+// buffer of a listener is full. If not used, OverflowBlock is used. Dropped
+// events are counted in Stats.
+func WithEventOverflow(policy OverflowPolicy) Opt {
+	return func(c *clientOpts) {
+		c.eventOverflow = policy
+	}
+}
+
+// DefaultEventReconnectPolicy is the default policy used to reconnect event
+// streams after a failure.
+var DefaultEventReconnectPolicy = RetryPolicy{
+	InitialDelay: time.Second,
+	MaxDelay:     time.Minute,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
+
+// WithEventReconnect sets the delays between the reconnection attempts of
+// event streams after a failure. The MaxAttempts and Retryable fields of the
+// policy are ignored: event streams are reconnected until they are closed. If
+// not used, DefaultEventReconnectPolicy is used.
+func WithEventReconnect(policy RetryPolicy) Opt {
+	return func(c *clientOpts) {
+		c.eventReconnect = policy
+	}
+}
+
+// WithStreamStatusEvents sends an event with a StreamReconnected status to
+// the listeners when the event stream is reconnected after a failure. Events
+// with a StreamDisconnected status are always sent, along with the error.
+func WithStreamStatusEvents() Opt {
+	return func(c *clientOpts) {
+		c.streamStatusEvents = true
+	}
+}
+
+type eventsRequest struct {
 	ChannelID int      `json:"channelid"`
 	Events    []string `json:"events"`
 }
 
-// Events watches the specified events until context is canceled.
-func (c *Client) Events(ctx context.Context, events []string) <-chan *response.Event {
-	el := &eventListener{
-		client:  c,
-		events:  events,
-		channel: make(chan *response.Event, 128),
+// Events watches the specified events until context is canceled or the
+// returned listener is closed. The events are received from the C channel of
+// the listener, which is closed when the listener stops.
+//
+// Events are the paths of the objects to watch, such as EventDevices, the
+// events of their children are also received. Paths may contain wildcards,
+// see Wildcard. All events are received if no event is given.
+//
+// The listeners of a client share a single event channel of the Livebox, that
+// watches all the requested events. Events are then dispatched to the
+// listeners that requested them.
+func (c *Client) Events(ctx context.Context, events []string) *Listener {
+	ch := make(chan *response.Event, c.eventBufferSize)
+	l := &Listener{
+		C:        ch,
+		client:   c,
+		events:   events,
+		channel:  ch,
+		done:     make(chan struct{}),
+		overflow: c.eventOverflow,
+	}
+
+	// The listener is stopped right away if the client is closed.
+	if c.closed.Load() {
+		l.err = ErrClientClosed
+		l.closeOnce.Do(l.close)
+
+		return l
 	}
-	go el.Run(ctx)
 
-	c.startEventSessionKeepAlive()
+	c.startKeepAlive()
+	c.events.add(c, l)
 
-	return el.channel
+	go func() {
+		select {
+		case <-ctx.Done():
+			l.setErr(ctx.Err())
+			l.Close()
+		case <-l.done:
+		}
+	}()
+
+	// The client was closed while the listener was added.
+	if c.closed.Load() {
+		l.setErr(ErrClientClosed)
+		l.Close()
+	}
+
+	return l
 }
 
-func (c *Client) requestEvent(ctx context.Context, req *events) (*response.Events, error) {
+// Deadline of event requests. The Livebox holds them until events are
+// available, they are not subject to the response header timeout of the
+// transport, but must not hang forever if the Livebox stops responding.
+const eventRequestTimeout = 10 * time.Minute
+
+func (c *Client) requestEvent(ctx context.Context, req *eventsRequest) (*response.Events, error) {
+	ctx, cancel := context.WithTimeout(ctx, eventRequestTimeout)
+	defer cancel()
+
 	for {
 		var events response.Events
 
@@ -47,89 +156,279 @@ func (c *Client) requestEvent(ctx context.Context, req *events) (*response.Event
 	}
 }
 
-// startEventSessionKeepAlive starts a goroutine that continuously sends a normal
-// livebox request to reauthenticate automatically if needed.
-func (c *Client) startEventSessionKeepAlive() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// watches returns true if a channel that watches the channel objects receives
+// the events of the requested objects. Nil means all the objects.
+func watches(channel, objects []string) bool {
+	if channel == nil {
+		return true
+	}
 
-	c.eventsCtr++
-	if c.eventsCtr == 1 {
-		c.log.Debug("Starting event session keepalive goroutine")
-		ch := make(chan struct{})
-		c.eventsStopCh = ch
+	if objects == nil {
+		return false
+	}
 
-		go func() {
-			defer close(ch)
+	for _, object := range objects {
+		if !slices.ContainsFunc(channel, func(watched string) bool {
+			return object == watched || strings.HasPrefix(object, watched+".")
+		}) {
+			return false
+		}
+	}
 
-			for {
-				out := json.RawMessage{}
-				if err := c.client.Request(
-					context.TODO(),
-					client.ContentTypeWS,
-					request.New("IoTService", "getStatus", nil),
-					&out,
-				); err != nil {
-					c.log.Debug("Failed to send session keepalive request", slog.Any("error", err))
-				}
+	return true
+}
 
-				select {
-				case <-ch:
-					c.log.Debug("Stopped event session keepalive goroutine")
-					return
-				case <-time.After(30 * time.Second):
-				}
-			}
-		}()
+// eventMux multiplexes the listeners of a client onto a single event channel
+// of the Livebox, because the Livebox limits the number of event channels.
+type eventMux struct {
+	// mu guards the following fields.
+	mu        sync.Mutex
+	listeners map[*Listener]struct{}
+	running   bool
+	// Interrupts the current event request, when listeners are added or
+	// removed.
+	cancelPoll context.CancelFunc
+	// Current event channel and the objects it watches.
+	channelID int
+	objects   []string
+}
+
+// all returns the listeners.
+func (m *eventMux) all() []*Listener {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	listeners := make([]*Listener, 0, len(m.listeners))
+	for l := range m.listeners {
+		listeners = append(listeners, l)
 	}
+
+	return listeners
 }
 
-func (c *Client) stopEventSessionKeepAlive() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// restore sets the event channel restored from a session store, it is reused
+// if it watches the objects of the listeners.
+func (m *eventMux) restore(channelID int, objects []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.channelID = channelID
+	m.objects = objects
+}
 
-	c.eventsCtr--
+// channel returns the current event channel and the objects it watches.
+func (m *eventMux) channel() (int, []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	if c.eventsCtr == 0 {
-		c.eventsStopCh <- struct{}{}
+	return m.channelID, m.objects
+}
+
+// setChannel sets the current event channel. It returns false if it did not
+// change.
+func (m *eventMux) setChannel(channelID int, objects []string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.channelID == channelID && slices.Equal(m.objects, objects) {
+		return false
 	}
+
+	m.channelID = channelID
+	m.objects = objects
+
+	return true
 }
 
-type eventListener struct {
-	client    *Client
-	channelID int
-	events    []string
-	channel   chan *response.Event
+// add adds a listener, the event channel is started if needed.
+func (m *eventMux) add(c *Client, l *Listener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.listeners == nil {
+		m.listeners = map[*Listener]struct{}{}
+	}
+	m.listeners[l] = struct{}{}
+
+	if m.cancelPoll != nil {
+		m.cancelPoll()
+	}
+
+	if !m.running {
+		m.running = true
+		go m.run(c)
+	}
 }
 
-func (el *eventListener) Run(ctx context.Context) {
-	defer el.client.stopEventSessionKeepAlive()
-	defer close(el.channel)
+// remove removes a listener, the event channel is stopped if there is no
+// listener left.
+func (m *eventMux) remove(l *Listener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.listeners, l)
+
+	if m.cancelPoll != nil {
+		m.cancelPoll()
+	}
+}
+
+// poll prepares the next event request. It returns the events requested by
+// the listeners and a snapshot of the listeners. The last return value is
+// false if there is no listener left.
+func (m *eventMux) poll() (context.Context, []string, []*Listener, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.listeners) == 0 {
+		m.running = false
+		m.cancelPoll = nil
+
+		return nil, nil, nil, false
+	}
+
+	var (
+		listeners = make([]*Listener, 0, len(m.listeners))
+		set       = map[string]struct{}{}
+		all       bool
+	)
+
+	for l := range m.listeners {
+		listeners = append(listeners, l)
+
+		// A listener without events receives all of them.
+		all = all || len(l.events) == 0
+		for _, event := range l.events {
+			object := eventObject(event)
+			all = all || object == ""
+			set[object] = struct{}{}
+		}
+	}
+
+	var events []string
+	if !all {
+		events = sortedKeys(set)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelPoll = cancel
+
+	return ctx, events, listeners, true
+}
+
+// run sends event requests and dispatches the events to the listeners, until
+// there is no listener left.
+func (m *eventMux) run(c *Client) {
+	// Reuse the restored channel, if any.
+	channelID, current := m.channel()
+
+	var (
+		// Consecutive failures and current reconnection delay.
+		failures int
+		delay    = c.eventReconnect.InitialDelay
+	)
 
 	for {
-		events, err := el.client.requestEvent(ctx, &events{ChannelID: el.channelID, Events: el.events})
+		ctx, events, listeners, ok := m.poll()
+		if !ok {
+			return
+		}
+
+		// The events of a channel cannot be changed. It is kept as long as
+		// it watches all the requested objects, so that adding and
+		// removing listeners does not create a new channel each time: the
+		// Livebox does not allow deleting channels, replaced ones are left
+		// to expire.
+		if channelID == 0 || !watches(current, events) {
+			channelID = 0
+			current = events
+		}
+
+		res, err := c.requestEvent(ctx, &eventsRequest{ChannelID: channelID, Events: current})
+
+		// Listeners were added or removed. Events that were received are
+		// still dispatched, they are lost otherwise.
+		if err != nil && ctx.Err() != nil {
+			continue
+		}
+
 		if err != nil {
+			c.metrics.observeError(err)
+			c.metrics.observeEventReconnect()
+
+			failures++
+			if failures > 1 {
+				delay = c.eventReconnect.next(delay)
+			}
+			retryIn := c.eventReconnect.jitter(delay)
+
+			for _, l := range listeners {
+				l.send(&response.Event{
+					Error: err,
+					Status: &response.StreamStatus{
+						State:    response.StreamDisconnected,
+						Failures: failures,
+						RetryIn:  retryIn,
+					},
+				})
+			}
+
+			channelID = 0
+
+			// Stop waiting if listeners are added or removed.
 			select {
 			case <-ctx.Done():
-				return
-			default:
+			case <-time.After(retryIn):
 			}
 
-			el.channel <- &response.Event{Error: err}
-			el.channelID = 0
-			time.Sleep(1 * time.Second) // TODO: retry with backoff?
 			continue
 		}
 
-		el.channelID = events.ChannelID
+		if failures > 0 {
+			for _, l := range listeners {
+				l.reconnected()
+
+				if c.streamStatusEvents {
+					l.send(&response.Event{
+						Status: &response.StreamStatus{State: response.StreamReconnected, Failures: failures},
+					})
+				}
+			}
+		}
+
+		failures = 0
+		delay = c.eventReconnect.InitialDelay
+
+		channelID = res.ChannelID
+		if m.setChannel(channelID, current) {
+			c.saveSession(context.Background())
+		}
+
+		received := time.Now()
 
-		for _, event := range events.Events {
+		for _, event := range res.Events {
 			event := event
-			select {
-			case <-ctx.Done():
-				return
-			case el.channel <- &response.Event{Event: &event.Data}:
+			c.metrics.observeEventReceived(event.Err)
+
+			if event.Err != nil {
+				for _, l := range listeners {
+					l.send(&response.Event{Raw: event.Raw, Error: fmt.Errorf("failed to decode event: %w", event.Err)})
+				}
+
+				continue
 			}
+
+			if c.eventDedup.duplicate(&event.Data) {
+				continue
+			}
+
+			for _, l := range listeners {
+				if l.wants(event.Data.Handler) {
+					l.send(&response.Event{Event: &event.Data, Raw: event.Raw})
+				}
+			}
+
+			c.metrics.observeEventDelivered(time.Since(received))
 		}
 	}
 }
@@ -0,0 +1,59 @@
+package livebox
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// eventRecord is an event written by WriteEvents.
+type eventRecord struct {
+	Time       time.Time      `json:"time"`
+	Handler    string         `json:"handler,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Raw JSON of the event.
+	Raw    json.RawMessage `json:"raw,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Status string          `json:"status,omitempty"`
+}
+
+// WriteEvents writes the events received from events to w as newline-delimited
+// JSON, with the time at which they were received, until events is closed:
+//
+//	l := client.Events(ctx, []string{livebox.EventDevices})
+//	err := livebox.WriteEvents(os.Stdout, l.C)
+//
+// Errors of the event stream are also written. It returns the first error
+// returned by w, the listener should then be closed as its events are no
+// longer received.
+func WriteEvents(w io.Writer, events <-chan *response.Event) error {
+	enc := json.NewEncoder(w)
+
+	for ev := range events {
+		rec := eventRecord{Time: time.Now()}
+
+		if ev.Event != nil {
+			rec.Handler = ev.Event.Handler
+			rec.Reason = ev.Event.Object.Reason
+			rec.Attributes = ev.Event.Object.Attributes
+			rec.Raw = ev.Raw
+		}
+
+		if ev.Error != nil {
+			rec.Error = ev.Error.Error()
+		}
+
+		if ev.Status != nil {
+			rec.Status = ev.Status.State.String()
+		}
+
+		if err := enc.Encode(&rec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package livebox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// expvarState is the state of a client published by Client.Expvar.
+type expvarState struct {
+	// "auto" until the version is detected, then "4" or "1".
+	APIVersion string
+	Closed     bool
+	Session    expvarSession
+	Events     expvarEvents
+	Stats      Stats
+}
+
+// expvarSession is the state of the session of a client. Secrets are not
+// published.
+type expvarSession struct {
+	Authenticated bool
+	Username      string
+	Groups        []string
+	CreatedAt     time.Time
+	// Time of the last successful request, other than event requests.
+	LastActivity time.Time
+	// Shortest duration without activity after which a session expired.
+	Lifetime time.Duration
+	// Interval between two keep-alive requests, negative if disabled.
+	KeepAliveInterval time.Duration
+}
+
+// expvarEvents is the state of the event stream of a client.
+type expvarEvents struct {
+	Running   bool
+	ChannelID int
+	// Objects watched by the event channel.
+	Objects   []string
+	Listeners []expvarListener
+}
+
+// expvarListener is the state of an event listener.
+type expvarListener struct {
+	// Objects requested by the listener, all if empty.
+	Objects []string
+	ListenerStats
+	// Number of events waiting in the buffer of the listener.
+	Buffered int
+}
+
+// Expvar describes the internals of a client: its session, its event stream
+// and listeners, and its statistics. It implements expvar.Var, so that
+// long-running programs can be inspected with the /debug/vars endpoint of the
+// expvar package:
+//
+//	expvar.Publish("livebox", client.Expvar())
+//
+// This package does not import expvar: the endpoint is only registered by
+// programs that do. Passwords and session tokens are not part of the
+// variable.
+type Expvar struct {
+	client *Client
+}
+
+// Expvar returns the variable describing the internals of the client.
+func (c *Client) Expvar() Expvar {
+	return Expvar{client: c}
+}
+
+// String returns the JSON encoding of the current state of the client.
+func (v Expvar) String() string {
+	b, err := json.Marshal(v.client.expvarState())
+	if err != nil {
+		return "null"
+	}
+
+	return string(b)
+}
+
+// expvarState returns the state published by Expvar.
+func (c *Client) expvarState() expvarState {
+	state := expvarState{
+		APIVersion: apiVersionName(c.APIVersion()),
+		Closed:     c.closed.Load(),
+		Session: expvarSession{
+			LastActivity:      c.client.LastActivity(),
+			Lifetime:          c.client.SessionLifetime(),
+			KeepAliveInterval: c.keepAlive.interval,
+		},
+		Stats: c.Stats(),
+	}
+
+	if info, ok := c.SessionInfo(); ok {
+		state.Session.Authenticated = true
+		state.Session.Username = info.Username
+		state.Session.Groups = info.Groups
+		state.Session.CreatedAt = info.CreatedAt
+	}
+
+	if state.Session.KeepAliveInterval > 0 {
+		state.Session.KeepAliveInterval = c.keepAliveInterval()
+	}
+
+	c.events.mu.Lock()
+	state.Events.Running = c.events.running
+	state.Events.ChannelID = c.events.channelID
+	state.Events.Objects = c.events.objects
+	c.events.mu.Unlock()
+
+	for _, l := range c.events.all() {
+		state.Events.Listeners = append(state.Events.Listeners, expvarListener{
+			Objects:       l.events,
+			ListenerStats: l.Stats(),
+			Buffered:      len(l.channel),
+		})
+	}
+
+	return state
+}
+
+// apiVersionName returns the name of an API version.
+func apiVersionName(v APIVersion) string {
+	switch v {
+	case APIVersion4:
+		return "4"
+	case APIVersion1:
+		return "1"
+	default:
+		return "auto"
+	}
+}
@@ -0,0 +1,272 @@
+package livebox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// Fixtures are responses of Livebox models, stored in
+// testdata/fixtures/<model>/<service>_<method>.json. See the README of the
+// directory for their origin.
+const fixturesDir = "testdata/fixtures"
+
+var fixtureTests = []struct {
+	service string
+	method  string
+	check   func(ctx context.Context, c *livebox.Client) error
+}{
+	{
+		service: "DeviceInfo",
+		method:  "get",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			info, err := c.GetDeviceInfo(ctx)
+			if err != nil {
+				return err
+			}
+
+			if info.ModelName == "" || info.SoftwareVersion == "" || info.Uptime() == 0 {
+				return fmt.Errorf("incomplete device info: %+v", info)
+			}
+
+			return nil
+		},
+	},
+	{
+		service: "NMC",
+		method:  "getWANStatus",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			status, err := c.GetWANStatus(ctx)
+			if err != nil {
+				return err
+			}
+
+			if status.IPAddress == "" || status.LinkType == "" {
+				return fmt.Errorf("incomplete WAN status: %+v", status)
+			}
+
+			return nil
+		},
+	},
+	{
+		service: "NMC",
+		method:  "get",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			mode, err := c.GetWANMode(ctx)
+			if err != nil {
+				return err
+			}
+
+			if mode.AccessType == response.AccessTypeUnknown {
+				return fmt.Errorf("unknown access type for WAN mode %q", mode.Mode)
+			}
+
+			return nil
+		},
+	},
+	{
+		service: "NMC",
+		method:  "getLANIP",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			cfg, err := c.GetLANConfig(ctx)
+			if err != nil {
+				return err
+			}
+
+			if cfg.Address == "" || cfg.Netmask == "" {
+				return fmt.Errorf("incomplete LAN config: %+v", cfg)
+			}
+
+			return nil
+		},
+	},
+	{
+		service: "Devices",
+		method:  "get",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			devices, err := c.Devices().List(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(devices) == 0 {
+				return errors.New("no device")
+			}
+
+			for _, device := range devices {
+				if device.Key == "" || device.PhysAddress == "" || device.LastConnection.IsZero() {
+					return fmt.Errorf("incomplete device: %+v", device)
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		service: "Devices",
+		method:  "get",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			decoders, err := c.GetTVDecoders(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(decoders) == 0 {
+				return errors.New("no TV decoder")
+			}
+
+			for _, decoder := range decoders {
+				if !slices.Contains(strings.Fields(decoder.Tags), "iptv") {
+					return fmt.Errorf("device %s is not a TV decoder: %q", decoder.Key, decoder.Tags)
+				}
+
+				if decoder.Layer2Interface == "" || (decoder.Active && decoder.IPAddress == "") {
+					return fmt.Errorf("incomplete TV decoder: %+v", decoder)
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		service: "TopologyDiagnostics",
+		method:  "buildTopology",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			root, err := c.GetTopology(ctx)
+			if err != nil {
+				return err
+			}
+
+			nodes := 0
+			root.Walk(func(*response.TopologyNode, int) bool {
+				nodes++
+				return true
+			})
+
+			if nodes < 2 {
+				return errors.New("topology has no children")
+			}
+
+			return nil
+		},
+	},
+	{
+		service: "VoiceService.VoiceApplication",
+		method:  "getCallList",
+		check: func(ctx context.Context, c *livebox.Client) error {
+			calls, err := c.GetCallList(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, call := range calls {
+				if call.ID == "" || call.StartTime.IsZero() {
+					return fmt.Errorf("incomplete call: %+v", call)
+				}
+			}
+
+			return nil
+		},
+	},
+}
+
+func TestFixtures(t *testing.T) {
+	models, err := os.ReadDir(fixturesDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, model := range models {
+		if !model.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(fixturesDir, model.Name())
+
+		t.Run(model.Name(), func(t *testing.T) {
+			srv := httptest.NewServer(fixtureHandler(dir))
+			defer srv.Close()
+
+			c, err := livebox.NewClient("password", livebox.WithAddress(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, tt := range fixtureTests {
+				t.Run(tt.service+":"+tt.method, func(t *testing.T) {
+					if _, err := os.Stat(fixturePath(dir, tt.service, tt.method)); err != nil {
+						t.Skip("no fixture for this model")
+					}
+
+					if err := tt.check(context.Background(), c); err != nil {
+						t.Error(err)
+					}
+				})
+			}
+		})
+	}
+}
+
+func fixturePath(dir, service, method string) string {
+	return filepath.Join(dir, service+"_"+method+".json")
+}
+
+// fixtureHandler returns a handler that accepts any login, and responds to
+// other requests with the fixture of the requested service and method. The
+// devices of Devices:get are filtered by the tag of the expression parameter.
+func fixtureHandler(dir string) http.Handler {
+	return &apiHandler{respond: func(w http.ResponseWriter, call *apiCall) {
+		b, err := os.ReadFile(fixturePath(dir, call.Service, call.Method))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		var params struct {
+			Expression string `json:"expression"`
+		}
+		_ = json.Unmarshal(call.Parameters, &params)
+
+		if call.Service == "Devices" && call.Method == "get" && params.Expression != "" {
+			if b, err = filterDevices(b, params.Expression); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		_, _ = w.Write(b)
+	}}
+}
+
+// filterDevices keeps the devices of a Devices:get response that have the tag
+// of the expression. Only expressions made of a single tag are supported.
+func filterDevices(b []byte, expression string) ([]byte, error) {
+	var res struct {
+		Status []map[string]any `json:"status"`
+	}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+
+	devices := []map[string]any{}
+	for _, device := range res.Status {
+		tags, _ := device["Tags"].(string)
+		if slices.Contains(strings.Fields(tags), expression) {
+			devices = append(devices, device)
+		}
+	}
+	res.Status = devices
+
+	return json.Marshal(res)
+}
@@ -0,0 +1,180 @@
+package livebox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// DefaultFleetConcurrency is the default maximum number of Liveboxes that are
+// called concurrently by FanOut.
+const DefaultFleetConcurrency = 16
+
+// Fleet manages the clients of several Liveboxes, for instance a Livebox and
+// its extenders, or the Liveboxes of many subscribers. Clients are identified
+// by a name. Fleet is thread safe.
+type Fleet struct {
+	// Maximum number of Liveboxes called concurrently by FanOut,
+	// DefaultFleetConcurrency is used if 0.
+	Concurrency int
+
+	opts       []Opt
+	httpClient *http.Client
+
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewFleet returns a new Fleet. The options are used for all the clients of
+// the fleet, they share the same HTTP client. The HTTP client is not shared if
+// TLS options are used, as they apply to each client.
+func NewFleet(opts ...Opt) *Fleet {
+	co := &clientOpts{}
+	for _, f := range opts {
+		f(co)
+	}
+
+	httpClient := co.httpClient
+	if httpClient == nil {
+		httpClient = newHTTPClient()
+	}
+
+	return &Fleet{
+		opts:       opts,
+		httpClient: httpClient,
+		clients:    map[string]*Client{},
+	}
+}
+
+// Add creates the client of a Livebox and adds it to the fleet. The options
+// are applied after the options of the fleet, they usually set the address
+// and the credentials of the Livebox. An existing client with the same name
+// is replaced and closed.
+func (f *Fleet) Add(name, password string, opts ...Opt) (*Client, error) {
+	all := make([]Opt, 0, len(f.opts)+len(opts)+1)
+	all = append(all, withSharedHTTPClient(f.httpClient))
+	all = append(all, f.opts...)
+	all = append(all, opts...)
+
+	c, err := NewClient(password, all...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client %q: %w", name, err)
+	}
+
+	f.mu.Lock()
+	old := f.clients[name]
+	f.clients[name] = c
+	f.mu.Unlock()
+
+	if old != nil {
+		_ = old.Close()
+	}
+
+	return c, nil
+}
+
+// Remove removes the client of a Livebox from the fleet, and closes it.
+func (f *Fleet) Remove(name string) error {
+	f.mu.Lock()
+	c, ok := f.clients[name]
+	delete(f.clients, name)
+	f.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return c.Close()
+}
+
+// Close closes and removes all the clients of the fleet, and closes the idle
+// connections of their HTTP client.
+func (f *Fleet) Close() error {
+	f.mu.Lock()
+	clients := f.clients
+	f.clients = map[string]*Client{}
+	f.mu.Unlock()
+
+	var errs []error
+	for _, name := range sortedKeys(clients) {
+		if err := clients[name].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close client %q: %w", name, err))
+		}
+	}
+
+	f.httpClient.CloseIdleConnections()
+
+	return errors.Join(errs...)
+}
+
+// Client returns the client of a Livebox. The second return value is false if
+// there is no client with this name.
+func (f *Fleet) Client(name string) (*Client, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	c, ok := f.clients[name]
+
+	return c, ok
+}
+
+// Names returns the sorted names of the clients of the fleet.
+func (f *Fleet) Names() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return sortedKeys(f.clients)
+}
+
+// FleetResult is the result of a call to a Livebox of a fleet.
+type FleetResult[T any] struct {
+	// Name of the Livebox.
+	Name  string
+	Value T
+	Err   error
+}
+
+// FanOut calls fn concurrently with the client of each Livebox of the fleet,
+// typically to call the same typed method on all of them:
+//
+//	results := livebox.FanOut(ctx, fleet, (*livebox.Client).GetWANStatus)
+//
+// The results are sorted by name. A failed call does not cancel the other
+// ones.
+func FanOut[T any](ctx context.Context, f *Fleet, fn func(ctx context.Context, c *Client) (T, error)) []FleetResult[T] {
+	f.mu.RLock()
+	results := make([]FleetResult[T], 0, len(f.clients))
+	clients := make([]*Client, 0, len(f.clients))
+	for name, c := range f.clients {
+		results = append(results, FleetResult[T]{Name: name})
+		clients = append(clients, c)
+	}
+	f.mu.RUnlock()
+
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFleetConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(r *FleetResult[T], c *Client) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			r.Value, r.Err = fn(ctx, c)
+		}(&results[i], clients[i])
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	return results
+}
@@ -0,0 +1,72 @@
+package livebox_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+func TestFleetClosesClients(t *testing.T) {
+	srv := httptest.NewServer(&apiHandler{respond: func(w http.ResponseWriter, _ *apiCall) {
+		_, _ = w.Write([]byte(`{"status":true}`))
+	}})
+	defer srv.Close()
+
+	f := livebox.NewFleet(livebox.WithAddress(srv.URL), livebox.WithoutKeepAlive())
+	ctx := context.Background()
+
+	add := func(name string) *livebox.Client {
+		c, err := f.Add(name, "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return c
+	}
+
+	closed := func(c *livebox.Client) bool {
+		_, err := c.GetWANStatus(ctx)
+		return errors.Is(err, livebox.ErrClientClosed)
+	}
+
+	replaced := add("home")
+	home := add("home")
+	office := add("office")
+	shop := add("shop")
+
+	if !closed(replaced) {
+		t.Error("expected the replaced client to be closed")
+	}
+
+	if closed(home) {
+		t.Error("expected the new client to be open")
+	}
+
+	if err := f.Remove("office"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !closed(office) {
+		t.Error("expected the removed client to be closed")
+	}
+
+	if closed(shop) {
+		t.Error("expected the other clients to keep working")
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !closed(home) || !closed(shop) {
+		t.Error("expected all the clients to be closed")
+	}
+
+	if names := f.Names(); len(names) != 0 {
+		t.Errorf("expected no client left, got %v", names)
+	}
+}
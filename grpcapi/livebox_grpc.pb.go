@@ -0,0 +1,778 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: livebox.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Livebox_GetDeviceInfo_FullMethodName       = "/livebox.v1.Livebox/GetDeviceInfo"
+	Livebox_Reboot_FullMethodName              = "/livebox.v1.Livebox/Reboot"
+	Livebox_GetWANStatus_FullMethodName        = "/livebox.v1.Livebox/GetWANStatus"
+	Livebox_ReconnectWAN_FullMethodName        = "/livebox.v1.Livebox/ReconnectWAN"
+	Livebox_ListDevices_FullMethodName         = "/livebox.v1.Livebox/ListDevices"
+	Livebox_SetDeviceName_FullMethodName       = "/livebox.v1.Livebox/SetDeviceName"
+	Livebox_WakeDevice_FullMethodName          = "/livebox.v1.Livebox/WakeDevice"
+	Livebox_BlockDevice_FullMethodName         = "/livebox.v1.Livebox/BlockDevice"
+	Livebox_UnblockDevice_FullMethodName       = "/livebox.v1.Livebox/UnblockDevice"
+	Livebox_GetWiFiStatus_FullMethodName       = "/livebox.v1.Livebox/GetWiFiStatus"
+	Livebox_SetWiFiEnabled_FullMethodName      = "/livebox.v1.Livebox/SetWiFiEnabled"
+	Livebox_GetGuestWiFi_FullMethodName        = "/livebox.v1.Livebox/GetGuestWiFi"
+	Livebox_SetGuestWiFiEnabled_FullMethodName = "/livebox.v1.Livebox/SetGuestWiFiEnabled"
+	Livebox_ListDHCPLeases_FullMethodName      = "/livebox.v1.Livebox/ListDHCPLeases"
+	Livebox_ListPortForwardings_FullMethodName = "/livebox.v1.Livebox/ListPortForwardings"
+	Livebox_ListCalls_FullMethodName           = "/livebox.v1.Livebox/ListCalls"
+	Livebox_WatchEvents_FullMethodName         = "/livebox.v1.Livebox/WatchEvents"
+)
+
+// LiveboxClient is the client API for Livebox service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Livebox exposes the typed API of a Livebox. Invalid arguments, such as
+// malformed MAC addresses, fail with INVALID_ARGUMENT, requests denied by the
+// Livebox with PERMISSION_DENIED, and requests that cannot reach it with
+// UNAVAILABLE.
+type LiveboxClient interface {
+	// Returns general information about the Livebox.
+	GetDeviceInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeviceInfo, error)
+	// Reboots the Livebox.
+	Reboot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the status of the WAN connection.
+	GetWANStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WANStatus, error)
+	// Renews the WAN connection.
+	ReconnectWAN(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the devices known by the Livebox.
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// Sets the name of a device.
+	SetDeviceName(ctx context.Context, in *SetDeviceNameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Sends a Wake-on-LAN magic packet to a device.
+	WakeDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Pauses the internet access of a device until it is unblocked.
+	BlockDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Resumes the internet access of a paused device.
+	UnblockDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the state of the Wi-Fi.
+	GetWiFiStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WiFiStatus, error)
+	// Enables or disables the Wi-Fi.
+	SetWiFiEnabled(ctx context.Context, in *SetEnabledRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns whether the guest Wi-Fi network is enabled.
+	GetGuestWiFi(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GuestWiFi, error)
+	// Enables or disables the guest Wi-Fi network.
+	SetGuestWiFiEnabled(ctx context.Context, in *SetEnabledRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the leases of the DHCP server.
+	ListDHCPLeases(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListDHCPLeasesResponse, error)
+	// Lists the port forwarding rules.
+	ListPortForwardings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPortForwardingsResponse, error)
+	// Lists the call history.
+	ListCalls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCallsResponse, error)
+	// Streams the events of the Livebox until the call is canceled.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type liveboxClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLiveboxClient(cc grpc.ClientConnInterface) LiveboxClient {
+	return &liveboxClient{cc}
+}
+
+func (c *liveboxClient) GetDeviceInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeviceInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceInfo)
+	err := c.cc.Invoke(ctx, Livebox_GetDeviceInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) Reboot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_Reboot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) GetWANStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WANStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WANStatus)
+	err := c.cc.Invoke(ctx, Livebox_GetWANStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) ReconnectWAN(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_ReconnectWAN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, Livebox_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) SetDeviceName(ctx context.Context, in *SetDeviceNameRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_SetDeviceName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) WakeDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_WakeDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) BlockDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_BlockDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) UnblockDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_UnblockDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) GetWiFiStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WiFiStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WiFiStatus)
+	err := c.cc.Invoke(ctx, Livebox_GetWiFiStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) SetWiFiEnabled(ctx context.Context, in *SetEnabledRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_SetWiFiEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) GetGuestWiFi(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GuestWiFi, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GuestWiFi)
+	err := c.cc.Invoke(ctx, Livebox_GetGuestWiFi_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) SetGuestWiFiEnabled(ctx context.Context, in *SetEnabledRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Livebox_SetGuestWiFiEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) ListDHCPLeases(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListDHCPLeasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDHCPLeasesResponse)
+	err := c.cc.Invoke(ctx, Livebox_ListDHCPLeases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) ListPortForwardings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListPortForwardingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPortForwardingsResponse)
+	err := c.cc.Invoke(ctx, Livebox_ListPortForwardings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) ListCalls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCallsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCallsResponse)
+	err := c.cc.Invoke(ctx, Livebox_ListCalls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *liveboxClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Livebox_ServiceDesc.Streams[0], Livebox_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Livebox_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+// LiveboxServer is the server API for Livebox service.
+// All implementations must embed UnimplementedLiveboxServer
+// for forward compatibility.
+//
+// Livebox exposes the typed API of a Livebox. Invalid arguments, such as
+// malformed MAC addresses, fail with INVALID_ARGUMENT, requests denied by the
+// Livebox with PERMISSION_DENIED, and requests that cannot reach it with
+// UNAVAILABLE.
+type LiveboxServer interface {
+	// Returns general information about the Livebox.
+	GetDeviceInfo(context.Context, *emptypb.Empty) (*DeviceInfo, error)
+	// Reboots the Livebox.
+	Reboot(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Returns the status of the WAN connection.
+	GetWANStatus(context.Context, *emptypb.Empty) (*WANStatus, error)
+	// Renews the WAN connection.
+	ReconnectWAN(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Lists the devices known by the Livebox.
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// Sets the name of a device.
+	SetDeviceName(context.Context, *SetDeviceNameRequest) (*emptypb.Empty, error)
+	// Sends a Wake-on-LAN magic packet to a device.
+	WakeDevice(context.Context, *DeviceRequest) (*emptypb.Empty, error)
+	// Pauses the internet access of a device until it is unblocked.
+	BlockDevice(context.Context, *DeviceRequest) (*emptypb.Empty, error)
+	// Resumes the internet access of a paused device.
+	UnblockDevice(context.Context, *DeviceRequest) (*emptypb.Empty, error)
+	// Returns the state of the Wi-Fi.
+	GetWiFiStatus(context.Context, *emptypb.Empty) (*WiFiStatus, error)
+	// Enables or disables the Wi-Fi.
+	SetWiFiEnabled(context.Context, *SetEnabledRequest) (*emptypb.Empty, error)
+	// Returns whether the guest Wi-Fi network is enabled.
+	GetGuestWiFi(context.Context, *emptypb.Empty) (*GuestWiFi, error)
+	// Enables or disables the guest Wi-Fi network.
+	SetGuestWiFiEnabled(context.Context, *SetEnabledRequest) (*emptypb.Empty, error)
+	// Lists the leases of the DHCP server.
+	ListDHCPLeases(context.Context, *emptypb.Empty) (*ListDHCPLeasesResponse, error)
+	// Lists the port forwarding rules.
+	ListPortForwardings(context.Context, *emptypb.Empty) (*ListPortForwardingsResponse, error)
+	// Lists the call history.
+	ListCalls(context.Context, *emptypb.Empty) (*ListCallsResponse, error)
+	// Streams the events of the Livebox until the call is canceled.
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedLiveboxServer()
+}
+
+// UnimplementedLiveboxServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLiveboxServer struct{}
+
+func (UnimplementedLiveboxServer) GetDeviceInfo(context.Context, *emptypb.Empty) (*DeviceInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceInfo not implemented")
+}
+func (UnimplementedLiveboxServer) Reboot(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reboot not implemented")
+}
+func (UnimplementedLiveboxServer) GetWANStatus(context.Context, *emptypb.Empty) (*WANStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWANStatus not implemented")
+}
+func (UnimplementedLiveboxServer) ReconnectWAN(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconnectWAN not implemented")
+}
+func (UnimplementedLiveboxServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedLiveboxServer) SetDeviceName(context.Context, *SetDeviceNameRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeviceName not implemented")
+}
+func (UnimplementedLiveboxServer) WakeDevice(context.Context, *DeviceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WakeDevice not implemented")
+}
+func (UnimplementedLiveboxServer) BlockDevice(context.Context, *DeviceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockDevice not implemented")
+}
+func (UnimplementedLiveboxServer) UnblockDevice(context.Context, *DeviceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockDevice not implemented")
+}
+func (UnimplementedLiveboxServer) GetWiFiStatus(context.Context, *emptypb.Empty) (*WiFiStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWiFiStatus not implemented")
+}
+func (UnimplementedLiveboxServer) SetWiFiEnabled(context.Context, *SetEnabledRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWiFiEnabled not implemented")
+}
+func (UnimplementedLiveboxServer) GetGuestWiFi(context.Context, *emptypb.Empty) (*GuestWiFi, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGuestWiFi not implemented")
+}
+func (UnimplementedLiveboxServer) SetGuestWiFiEnabled(context.Context, *SetEnabledRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGuestWiFiEnabled not implemented")
+}
+func (UnimplementedLiveboxServer) ListDHCPLeases(context.Context, *emptypb.Empty) (*ListDHCPLeasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDHCPLeases not implemented")
+}
+func (UnimplementedLiveboxServer) ListPortForwardings(context.Context, *emptypb.Empty) (*ListPortForwardingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPortForwardings not implemented")
+}
+func (UnimplementedLiveboxServer) ListCalls(context.Context, *emptypb.Empty) (*ListCallsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalls not implemented")
+}
+func (UnimplementedLiveboxServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedLiveboxServer) mustEmbedUnimplementedLiveboxServer() {}
+func (UnimplementedLiveboxServer) testEmbeddedByValue()                 {}
+
+// UnsafeLiveboxServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LiveboxServer will
+// result in compilation errors.
+type UnsafeLiveboxServer interface {
+	mustEmbedUnimplementedLiveboxServer()
+}
+
+func RegisterLiveboxServer(s grpc.ServiceRegistrar, srv LiveboxServer) {
+	// If the following call pancis, it indicates UnimplementedLiveboxServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Livebox_ServiceDesc, srv)
+}
+
+func _Livebox_GetDeviceInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).GetDeviceInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_GetDeviceInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).GetDeviceInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_Reboot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).Reboot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_Reboot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).Reboot(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_GetWANStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).GetWANStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_GetWANStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).GetWANStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_ReconnectWAN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).ReconnectWAN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_ReconnectWAN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).ReconnectWAN(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_SetDeviceName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).SetDeviceName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_SetDeviceName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).SetDeviceName(ctx, req.(*SetDeviceNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_WakeDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).WakeDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_WakeDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).WakeDevice(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_BlockDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).BlockDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_BlockDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).BlockDevice(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_UnblockDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).UnblockDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_UnblockDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).UnblockDevice(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_GetWiFiStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).GetWiFiStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_GetWiFiStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).GetWiFiStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_SetWiFiEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).SetWiFiEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_SetWiFiEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).SetWiFiEnabled(ctx, req.(*SetEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_GetGuestWiFi_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).GetGuestWiFi(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_GetGuestWiFi_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).GetGuestWiFi(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_SetGuestWiFiEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).SetGuestWiFiEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_SetGuestWiFiEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).SetGuestWiFiEnabled(ctx, req.(*SetEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_ListDHCPLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).ListDHCPLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_ListDHCPLeases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).ListDHCPLeases(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_ListPortForwardings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).ListPortForwardings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_ListPortForwardings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).ListPortForwardings(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_ListCalls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LiveboxServer).ListCalls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Livebox_ListCalls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LiveboxServer).ListCalls(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Livebox_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LiveboxServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Livebox_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+// Livebox_ServiceDesc is the grpc.ServiceDesc for Livebox service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Livebox_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "livebox.v1.Livebox",
+	HandlerType: (*LiveboxServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDeviceInfo",
+			Handler:    _Livebox_GetDeviceInfo_Handler,
+		},
+		{
+			MethodName: "Reboot",
+			Handler:    _Livebox_Reboot_Handler,
+		},
+		{
+			MethodName: "GetWANStatus",
+			Handler:    _Livebox_GetWANStatus_Handler,
+		},
+		{
+			MethodName: "ReconnectWAN",
+			Handler:    _Livebox_ReconnectWAN_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _Livebox_ListDevices_Handler,
+		},
+		{
+			MethodName: "SetDeviceName",
+			Handler:    _Livebox_SetDeviceName_Handler,
+		},
+		{
+			MethodName: "WakeDevice",
+			Handler:    _Livebox_WakeDevice_Handler,
+		},
+		{
+			MethodName: "BlockDevice",
+			Handler:    _Livebox_BlockDevice_Handler,
+		},
+		{
+			MethodName: "UnblockDevice",
+			Handler:    _Livebox_UnblockDevice_Handler,
+		},
+		{
+			MethodName: "GetWiFiStatus",
+			Handler:    _Livebox_GetWiFiStatus_Handler,
+		},
+		{
+			MethodName: "SetWiFiEnabled",
+			Handler:    _Livebox_SetWiFiEnabled_Handler,
+		},
+		{
+			MethodName: "GetGuestWiFi",
+			Handler:    _Livebox_GetGuestWiFi_Handler,
+		},
+		{
+			MethodName: "SetGuestWiFiEnabled",
+			Handler:    _Livebox_SetGuestWiFiEnabled_Handler,
+		},
+		{
+			MethodName: "ListDHCPLeases",
+			Handler:    _Livebox_ListDHCPLeases_Handler,
+		},
+		{
+			MethodName: "ListPortForwardings",
+			Handler:    _Livebox_ListPortForwardings_Handler,
+		},
+		{
+			MethodName: "ListCalls",
+			Handler:    _Livebox_ListCalls_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Livebox_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "livebox.proto",
+}
@@ -0,0 +1,362 @@
+// Package grpcapi exposes the typed API of a Livebox client as a gRPC service,
+// defined in livebox.proto, so that clients in any language can use the
+// Livebox with generated, typed stubs, and receive its events as a stream:
+//
+//	auth, err := grpcapi.TokenAuth(token)
+//	if err != nil {
+//		return err
+//	}
+//
+//	srv := grpc.NewServer(auth...)
+//	grpcapi.RegisterLiveboxServer(srv, grpcapi.NewServer(client))
+//
+//	lis, err := net.Listen("tcp", "localhost:9119")
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = srv.Serve(lis)
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative livebox.proto
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements LiveboxServer with a Livebox client.
+type Server struct {
+	UnimplementedLiveboxServer
+
+	client *livebox.Client
+}
+
+// NewServer returns a server for the Livebox of client.
+func NewServer(client *livebox.Client) *Server {
+	return &Server{client: client}
+}
+
+// ErrEmptyToken is returned by TokenAuth when the token is empty.
+var ErrEmptyToken = errors.New("the token of the server must not be empty")
+
+// TokenAuth returns the options of a gRPC server that only accepts calls
+// with an "authorization: Bearer <token>" metadata. Other calls fail with
+// UNAUTHENTICATED.
+func TokenAuth(token string) ([]grpc.ServerOption, error) {
+	if token == "" {
+		return nil, ErrEmptyToken
+	}
+
+	check := func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, v := range md.Get("authorization") {
+			scheme, t, ok := strings.Cut(v, " ")
+			if ok && strings.EqualFold(scheme, "Bearer") && subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+				return nil
+			}
+		}
+
+		return status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := check(ctx); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := check(ss.Context()); err != nil {
+				return err
+			}
+
+			return handler(srv, ss)
+		}),
+	}, nil
+}
+
+// GetDeviceInfo implements LiveboxServer.
+func (s *Server) GetDeviceInfo(ctx context.Context, _ *emptypb.Empty) (*DeviceInfo, error) {
+	info, err := s.client.GetDeviceInfo(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &DeviceInfo{
+		Manufacturer:    info.Manufacturer,
+		ModelName:       info.ModelName,
+		ProductClass:    info.ProductClass,
+		SerialNumber:    info.SerialNumber,
+		HardwareVersion: info.HardwareVersion,
+		SoftwareVersion: info.SoftwareVersion,
+		BaseMac:         info.BaseMAC,
+		Uptime:          info.UpTime,
+		NumberOfReboots: int32(info.NumberOfReboots),
+	}, nil
+}
+
+// Reboot implements LiveboxServer.
+func (s *Server) Reboot(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	return empty(s.client.Reboot(ctx))
+}
+
+// GetWANStatus implements LiveboxServer.
+func (s *Server) GetWANStatus(ctx context.Context, _ *emptypb.Empty) (*WANStatus, error) {
+	wan, err := s.client.GetWANStatus(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &WANStatus{
+		LinkType:            wan.LinkType,
+		LinkState:           wan.LinkState,
+		Protocol:            wan.Protocol,
+		ConnectionState:     wan.ConnectionState,
+		LastConnectionError: wan.LastConnectionError,
+		IpAddress:           wan.IPAddress,
+		RemoteGateway:       wan.RemoteGateway,
+		DnsServers:          wan.DNSServers,
+		Ipv6Address:         wan.IPv6Address,
+		MacAddress:          wan.MACAddress,
+	}, nil
+}
+
+// ReconnectWAN implements LiveboxServer.
+func (s *Server) ReconnectWAN(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	return empty(s.client.ReconnectWAN(ctx))
+}
+
+// ListDevices implements LiveboxServer.
+func (s *Server) ListDevices(ctx context.Context, req *ListDevicesRequest) (*ListDevicesResponse, error) {
+	devices, err := s.client.Devices().List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &ListDevicesResponse{}
+	for _, d := range devices {
+		if req.GetActiveOnly() && !d.Active {
+			continue
+		}
+
+		resp.Devices = append(resp.Devices, &Device{
+			Key:             d.Key,
+			Name:            d.Name,
+			DeviceType:      d.DeviceType,
+			Active:          d.Active,
+			Tags:            d.Tags,
+			MacAddress:      d.PhysAddress,
+			IpAddress:       d.IPAddress,
+			Layer2Interface: d.Layer2Interface,
+			LastConnection:  timestamppb.New(d.LastConnection),
+			LastChanged:     timestamppb.New(d.LastChanged),
+		})
+	}
+
+	return resp, nil
+}
+
+// SetDeviceName implements LiveboxServer.
+func (s *Server) SetDeviceName(ctx context.Context, req *SetDeviceNameRequest) (*emptypb.Empty, error) {
+	return empty(s.client.Devices().SetName(ctx, req.GetMacAddress(), req.GetName()))
+}
+
+// WakeDevice implements LiveboxServer.
+func (s *Server) WakeDevice(ctx context.Context, req *DeviceRequest) (*emptypb.Empty, error) {
+	return empty(s.client.Devices().WakeOnLAN(ctx, req.GetMacAddress()))
+}
+
+// BlockDevice implements LiveboxServer.
+func (s *Server) BlockDevice(ctx context.Context, req *DeviceRequest) (*emptypb.Empty, error) {
+	return empty(s.client.Devices().Block(ctx, req.GetMacAddress()))
+}
+
+// UnblockDevice implements LiveboxServer.
+func (s *Server) UnblockDevice(ctx context.Context, req *DeviceRequest) (*emptypb.Empty, error) {
+	return empty(s.client.Devices().Unblock(ctx, req.GetMacAddress()))
+}
+
+// GetWiFiStatus implements LiveboxServer.
+func (s *Server) GetWiFiStatus(ctx context.Context, _ *emptypb.Empty) (*WiFiStatus, error) {
+	wifi, err := s.client.GetWiFiStatus(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &WiFiStatus{Enabled: wifi.Enable, Up: wifi.Status}, nil
+}
+
+// SetWiFiEnabled implements LiveboxServer.
+func (s *Server) SetWiFiEnabled(ctx context.Context, req *SetEnabledRequest) (*emptypb.Empty, error) {
+	return empty(s.client.SetWiFiEnabled(ctx, req.GetEnabled()))
+}
+
+// GetGuestWiFi implements LiveboxServer.
+func (s *Server) GetGuestWiFi(ctx context.Context, _ *emptypb.Empty) (*GuestWiFi, error) {
+	enabled, err := s.client.GetGuestWiFi(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &GuestWiFi{Enabled: enabled}, nil
+}
+
+// SetGuestWiFiEnabled implements LiveboxServer.
+func (s *Server) SetGuestWiFiEnabled(ctx context.Context, req *SetEnabledRequest) (*emptypb.Empty, error) {
+	return empty(s.client.SetGuestWiFiEnabled(ctx, req.GetEnabled()))
+}
+
+// ListDHCPLeases implements LiveboxServer.
+func (s *Server) ListDHCPLeases(ctx context.Context, _ *emptypb.Empty) (*ListDHCPLeasesResponse, error) {
+	leases, err := s.client.GetDHCPLeases(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &ListDHCPLeasesResponse{}
+	for _, l := range leases {
+		resp.Leases = append(resp.Leases, &DHCPLease{
+			FriendlyName:       l.FriendlyName,
+			MacAddress:         l.MACAddress,
+			IpAddress:          l.IPAddress,
+			Active:             l.Active,
+			Reserved:           l.Reserved,
+			LeaseTimeRemaining: int32(l.LeaseTimeRemaining),
+		})
+	}
+
+	return resp, nil
+}
+
+// ListPortForwardings implements LiveboxServer.
+func (s *Server) ListPortForwardings(ctx context.Context, _ *emptypb.Empty) (*ListPortForwardingsResponse, error) {
+	rules, err := s.client.GetPortForwardings(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &ListPortForwardingsResponse{}
+	for _, r := range rules {
+		resp.Rules = append(resp.Rules, &PortForwardingRule{
+			Id:                   r.ID,
+			Origin:               r.Origin,
+			Description:          r.Description,
+			Enabled:              r.Enable,
+			Status:               r.Status,
+			Protocol:             r.Protocol,
+			ExternalPort:         r.ExternalPort,
+			InternalPort:         r.InternalPort,
+			DestinationIpAddress: r.DestinationIPAddress,
+			SourcePrefix:         r.SourcePrefix,
+		})
+	}
+
+	return resp, nil
+}
+
+// ListCalls implements LiveboxServer.
+func (s *Server) ListCalls(ctx context.Context, _ *emptypb.Empty) (*ListCallsResponse, error) {
+	calls, err := s.client.GetCallList(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &ListCallsResponse{}
+	for _, c := range calls {
+		resp.Calls = append(resp.Calls, &Call{
+			Id:           c.ID,
+			Type:         string(c.Type),
+			Origin:       string(c.Origin),
+			RemoteNumber: c.RemoteNumber,
+			RemoteName:   c.RemoteName,
+			StartTime:    timestamppb.New(c.StartTime),
+			Duration:     int32(c.Duration),
+		})
+	}
+
+	return resp, nil
+}
+
+// WatchEvents implements LiveboxServer. Errors of the event stream of the
+// Livebox are not sent, it is reconnected by the client.
+func (s *Server) WatchEvents(req *WatchEventsRequest, stream grpc.ServerStreamingServer[Event]) error {
+	ctx := stream.Context()
+
+	l := s.client.Events(ctx, req.GetObjects())
+	defer l.Close()
+
+	for ev := range l.C {
+		if ev.Event == nil {
+			continue
+		}
+
+		// Attributes that cannot be represented are not sent.
+		attributes, _ := structpb.NewStruct(ev.Event.Object.Attributes)
+
+		if err := stream.Send(&Event{
+			Handler:    ev.Event.Handler,
+			Reason:     ev.Event.Object.Reason,
+			Attributes: attributes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	return toStatus(l.Err())
+}
+
+// empty returns an empty response, or the status of err.
+func empty(err error) (*emptypb.Empty, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// toStatus returns the gRPC status of an error returned by the client.
+func toStatus(err error) error {
+	var (
+		addrErr *net.AddrError
+		urlErr  *url.Error
+		apiErr  *response.Error
+	)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &addrErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, livebox.ErrInsufficientPermissions):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.As(err, &urlErr):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.As(err, &apiErr), errors.Is(err, livebox.ErrUnsuccessful):
+		return status.Error(codes.Internal, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
@@ -0,0 +1,41 @@
+package livebox_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// apiCall is a request to the Livebox API received by an apiHandler.
+type apiCall struct {
+	Service    string          `json:"service"`
+	Method     string          `json:"method"`
+	Parameters json.RawMessage `json:"parameters"`
+	// Context ID sent with the request.
+	Context string `json:"-"`
+}
+
+// apiHandler is a handler that accepts any login, creating sessions with the
+// "new" context, and passes the other requests to respond.
+type apiHandler struct {
+	respond func(w http.ResponseWriter, call *apiCall)
+	// Number of logins.
+	logins atomic.Int32
+}
+
+func (h *apiHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "X-Sah-Login" {
+		h.logins.Add(1)
+		w.Header().Add("Set-Cookie", "0123abcd/sessid=new; path=/")
+		_, _ = w.Write([]byte(`{"status":0,"data":{"contextID":"new","username":"admin","groups":"http,admin"}}`))
+		return
+	}
+
+	call := &apiCall{Context: r.Header.Get("X-Context")}
+	if err := json.NewDecoder(r.Body).Decode(call); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.respond(w, call)
+}
@@ -0,0 +1,30 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetIGMPConfig returns the configuration of the IGMP proxy and snooping.
+func (c *Client) GetIGMPConfig(ctx context.Context) (*response.IGMPConfig, error) {
+	var out response.Status[response.IGMPConfig]
+	if err := c.Request(ctx, sah.IGMPProxy.Get.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Status, nil
+}
+
+// SetIGMPConfig sets the configuration of the IGMP proxy and snooping.
+// Disabling the IGMP proxy breaks the TV service.
+func (c *Client) SetIGMPConfig(ctx context.Context, cfg *response.IGMPConfig) error {
+	return c.requestBool(ctx, sah.IGMPProxy.Set.Request(request.Parameters{
+		"ProxyEnable":    cfg.ProxyEnable,
+		"ProxyVersion":   cfg.ProxyVersion,
+		"SnoopingEnable": cfg.SnoopingEnable,
+		"FastLeave":      cfg.FastLeave,
+	}))
+}
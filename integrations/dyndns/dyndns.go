@@ -0,0 +1,251 @@
+// Package dyndns keeps DNS records up to date with the public addresses of a
+// Livebox. It listens to the NMC and WAN events of the client, polls the WAN
+// status in case an event was missed, and calls an Updater when the addresses
+// change: a dynamic DNS provider, or a user-provided hook:
+//
+//	u := &dyndns.DynDNS2{
+//		Server:   "dynupdate.no-ip.com",
+//		Hostname: "home.example.com",
+//		Username: "user",
+//		Password: "password",
+//	}
+//
+//	err := dyndns.New(client, u).Run(ctx)
+package dyndns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// DefaultPollInterval is the default interval at which the addresses are
+// checked, in case an event was missed.
+const DefaultPollInterval = 5 * time.Minute
+
+// DefaultRetryInterval is the default delay before a failed update is
+// retried.
+const DefaultRetryInterval = time.Minute
+
+// Delay between an event of the WAN connection and the check of the
+// addresses: events are sent in bursts when the connection changes.
+const settleDelay = 2 * time.Second
+
+// Addresses are the public addresses of the Livebox. An address is invalid
+// if the Livebox does not have one.
+type Addresses struct {
+	IPv4 netip.Addr
+	IPv6 netip.Addr
+}
+
+// String returns the valid addresses, separated by a comma.
+func (a Addresses) String() string {
+	switch {
+	case a.IPv4.IsValid() && a.IPv6.IsValid():
+		return a.IPv4.String() + "," + a.IPv6.String()
+	case a.IPv4.IsValid():
+		return a.IPv4.String()
+	case a.IPv6.IsValid():
+		return a.IPv6.String()
+	default:
+		return ""
+	}
+}
+
+// Updater updates DNS records with the public addresses of the Livebox.
+type Updater interface {
+	Update(ctx context.Context, addrs Addresses) error
+}
+
+// UpdaterFunc is a function that implements Updater.
+type UpdaterFunc func(ctx context.Context, addrs Addresses) error
+
+// Update calls f.
+func (f UpdaterFunc) Update(ctx context.Context, addrs Addresses) error {
+	return f(ctx, addrs)
+}
+
+// Updaters returns an Updater that calls all the updaters, even if some of
+// them fail. The update is retried for all of them if one fails, updaters
+// must accept to be called again with the same addresses.
+func Updaters(updaters ...Updater) Updater {
+	return UpdaterFunc(func(ctx context.Context, addrs Addresses) error {
+		var errs []error
+
+		for _, u := range updaters {
+			if err := u.Update(ctx, addrs); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// Daemon calls an Updater when the public addresses of a Livebox change.
+type Daemon struct {
+	client  *livebox.Client
+	updater Updater
+	poll    time.Duration
+	retry   time.Duration
+	log     *slog.Logger
+
+	// Addresses of the last successful update.
+	updated Addresses
+}
+
+// Opt is a Daemon option.
+type Opt func(d *Daemon)
+
+// WithPollInterval sets the interval at which the addresses are checked, in
+// addition to the checks triggered by events. If not used,
+// DefaultPollInterval is used.
+func WithPollInterval(interval time.Duration) Opt {
+	return func(d *Daemon) {
+		d.poll = interval
+	}
+}
+
+// WithRetryInterval sets the delay before a failed update is retried. If not
+// used, DefaultRetryInterval is used.
+func WithRetryInterval(interval time.Duration) Opt {
+	return func(d *Daemon) {
+		d.retry = interval
+	}
+}
+
+// WithLogger attaches a logger to the daemon, updates and their failures are
+// logged. Logging is disabled if unset.
+func WithLogger(log *slog.Logger) Opt {
+	return func(d *Daemon) {
+		d.log = log
+	}
+}
+
+// New returns a daemon that calls updater when the public addresses of the
+// Livebox of client change.
+func New(client *livebox.Client, updater Updater, opts ...Opt) *Daemon {
+	d := &Daemon{
+		client:  client,
+		updater: updater,
+		poll:    DefaultPollInterval,
+		retry:   DefaultRetryInterval,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.log == nil {
+		d.log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return d
+}
+
+// Run checks the addresses until ctx is canceled, or until the event stream
+// fails permanently. The updater is called once the addresses are known, then
+// each time they change. Failed updates are retried.
+func (d *Daemon) Run(ctx context.Context) error {
+	// Watch events first, so that no change is missed.
+	l := d.client.Events(ctx, []string{livebox.EventNMC, livebox.EventWAN})
+	defer l.Close()
+
+	ticker := time.NewTicker(d.poll)
+	defer ticker.Stop()
+
+	// Fires when the addresses must be checked again, after an event or a
+	// failure.
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.check(ctx, timer)
+		case <-timer.C:
+			d.check(ctx, timer)
+		case ev, ok := <-l.C:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				return l.Err()
+			}
+
+			if ev.Error != nil && ev.Status == nil {
+				d.log.WarnContext(ctx, "Event stream error", slog.Any("error", ev.Error))
+				continue
+			}
+
+			// Changes may have been missed while the stream was
+			// disconnected.
+			if ev.Event != nil || (ev.Status != nil && ev.Status.State == response.StreamReconnected) {
+				timer.Reset(settleDelay)
+			}
+		}
+	}
+}
+
+// check calls the updater if the addresses changed since the last successful
+// update. The timer is reset to retry if the check fails.
+func (d *Daemon) check(ctx context.Context, retry *time.Timer) {
+	addrs, err := d.addresses(ctx)
+	if err != nil {
+		d.log.WarnContext(ctx, "Failed to get public addresses", slog.Any("error", err))
+		retry.Reset(d.retry)
+
+		return
+	}
+
+	// The connection is down, the records are kept until it is up again.
+	if !addrs.IPv4.IsValid() && !addrs.IPv6.IsValid() {
+		return
+	}
+
+	if addrs == d.updated {
+		return
+	}
+
+	if err := d.updater.Update(ctx, addrs); err != nil {
+		d.log.WarnContext(ctx, "Failed to update DNS records",
+			slog.String("addresses", addrs.String()), slog.Any("error", err))
+		retry.Reset(d.retry)
+
+		return
+	}
+
+	d.log.InfoContext(ctx, "Updated DNS records", slog.String("addresses", addrs.String()))
+
+	d.updated = addrs
+}
+
+// addresses returns the public addresses of the Livebox.
+func (d *Daemon) addresses(ctx context.Context) (Addresses, error) {
+	status, err := d.client.GetWANStatus(ctx)
+	if err != nil {
+		return Addresses{}, fmt.Errorf("failed to get WAN status: %w", err)
+	}
+
+	var addrs Addresses
+
+	if addr, err := netip.ParseAddr(status.IPAddress); err == nil && addr.Is4() && !addr.IsUnspecified() {
+		addrs.IPv4 = addr
+	}
+
+	if addr, err := netip.ParseAddr(status.IPv6Address); err == nil && addr.Is6() && addr.IsGlobalUnicast() {
+		addrs.IPv6 = addr
+	}
+
+	return addrs, nil
+}
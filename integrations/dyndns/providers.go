@@ -0,0 +1,184 @@
+package dyndns
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ErrUpdateRejected is returned when a provider rejects an update.
+var ErrUpdateRejected = errors.New("the update was rejected by the provider")
+
+// User agent of the requests sent to the providers.
+const userAgent = "livebox-api-client-dyndns/1.0"
+
+// Maximum size of the responses of the providers that are read.
+const maxResponseSize = 4096
+
+// DynDNS2 updates records with the dyndns2 protocol, supported by most
+// dynamic DNS providers, such as Dyn, No-IP, OVH or Infomaniak.
+type DynDNS2 struct {
+	// Host name of the update server, such as "dynupdate.no-ip.com".
+	Server string
+	// Host names to update, separated by commas.
+	Hostname string
+	Username string
+	Password string
+	// HTTP client used to send the updates, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// Update implements Updater. Both addresses are sent if they are valid.
+func (p *DynDNS2) Update(ctx context.Context, addrs Addresses) error {
+	u := url.URL{
+		Scheme: "https",
+		Host:   p.Server,
+		Path:   "/nic/update",
+		RawQuery: url.Values{
+			"hostname": {p.Hostname},
+			"myip":     {addrs.String()},
+		}.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth(p.Username, p.Password)
+
+	body, err := send(p.Client, req)
+	if err != nil {
+		return err
+	}
+
+	// The response has one line per host name, starting with a code.
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		code, _, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if code != "good" && code != "nochg" {
+			return fmt.Errorf("%w: %s", ErrUpdateRejected, strings.TrimSpace(line))
+		}
+	}
+
+	return nil
+}
+
+// DuckDNS updates records of Duck DNS.
+type DuckDNS struct {
+	// Subdomains to update, without the duckdns.org suffix, separated by
+	// commas.
+	Domains string
+	Token   string
+	// HTTP client used to send the updates, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// Update implements Updater. Both addresses are sent if they are valid.
+func (p *DuckDNS) Update(ctx context.Context, addrs Addresses) error {
+	q := url.Values{
+		"domains": {p.Domains},
+		"token":   {p.Token},
+	}
+
+	if addrs.IPv4.IsValid() {
+		q.Set("ip", addrs.IPv4.String())
+	}
+
+	if addrs.IPv6.IsValid() {
+		q.Set("ipv6", addrs.IPv6.String())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.duckdns.org/update?"+q.Encode(), nil)
+	if err != nil {
+		return withoutURL(err)
+	}
+
+	body, err := send(p.Client, req)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(body) != "OK" {
+		return fmt.Errorf("%w: %s", ErrUpdateRejected, strings.TrimSpace(body))
+	}
+
+	return nil
+}
+
+// send sends a request to a provider and returns the body of the response.
+func send(client *http.Client, req *http.Request) (string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", withoutURL(err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: unexpected status %s: %s",
+			ErrUpdateRejected, res.Status, strings.TrimSpace(string(body)))
+	}
+
+	return string(body), nil
+}
+
+// withoutURL removes the URL from the message of a *url.Error, its query may
+// contain credentials, such as the token of Duck DNS, that must not be logged.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	return fmt.Errorf("%s request failed: %w", urlErr.Op, urlErr.Err)
+}
+
+// Command returns an Updater that runs a command, with the addresses in the
+// LIVEBOX_IPV4 and LIVEBOX_IPV6 environment variables, empty if the Livebox
+// does not have an address. The update fails if the command fails.
+func Command(name string, args ...string) Updater {
+	return UpdaterFunc(func(ctx context.Context, addrs Addresses) error {
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Env = append(os.Environ(),
+			"LIVEBOX_IPV4="+addrString(addrs.IPv4),
+			"LIVEBOX_IPV6="+addrString(addrs.IPv6),
+		)
+
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to run %s: %w: %s", name, err, strings.TrimSpace(out.String()))
+		}
+
+		return nil
+	})
+}
+
+// addrString returns an address, or an empty string if it is invalid.
+func addrString(addr netip.Addr) string {
+	if !addr.IsValid() {
+		return ""
+	}
+
+	return addr.String()
+}
@@ -0,0 +1,200 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// DefaultDiscoveryPrefix is the default prefix of the Home Assistant
+// discovery topics.
+const DefaultDiscoveryPrefix = "homeassistant"
+
+// WithHomeAssistant publishes Home Assistant MQTT discovery payloads under
+// prefix, so that entities are created automatically for the Livebox: a
+// device tracker for each device, a connectivity binary sensor for the WAN
+// link, and sensors for the throughput of the interfaces. If prefix is empty,
+// DefaultDiscoveryPrefix is used.
+//
+// The entities of devices removed from the Livebox are removed from Home
+// Assistant.
+func WithHomeAssistant(prefix string) Opt {
+	return func(b *Bridge) {
+		if prefix == "" {
+			prefix = DefaultDiscoveryPrefix
+		}
+
+		b.discoveryPrefix = prefix
+	}
+}
+
+// haDevice is a device in a discovery payload.
+type haDevice struct {
+	Identifiers  []string    `json:"identifiers,omitempty"`
+	Connections  [][2]string `json:"connections,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	Manufacturer string      `json:"manufacturer,omitempty"`
+	Model        string      `json:"model,omitempty"`
+	SWVersion    string      `json:"sw_version,omitempty"`
+	HWVersion    string      `json:"hw_version,omitempty"`
+	ViaDevice    string      `json:"via_device,omitempty"`
+}
+
+// haEntity is a discovery payload, the configuration of an entity.
+type haEntity struct {
+	Name                string   `json:"name"`
+	UniqueID            string   `json:"unique_id"`
+	StateTopic          string   `json:"state_topic"`
+	ValueTemplate       string   `json:"value_template,omitempty"`
+	JSONAttributesTopic string   `json:"json_attributes_topic,omitempty"`
+	SourceType          string   `json:"source_type,omitempty"`
+	DeviceClass         string   `json:"device_class,omitempty"`
+	StateClass          string   `json:"state_class,omitempty"`
+	UnitOfMeasurement   string   `json:"unit_of_measurement,omitempty"`
+	Icon                string   `json:"icon,omitempty"`
+	AvailabilityTopic   string   `json:"availability_topic,omitempty"`
+	PayloadAvailable    string   `json:"payload_available,omitempty"`
+	PayloadNotAvailable string   `json:"payload_not_available,omitempty"`
+	Device              haDevice `json:"device"`
+}
+
+// discoverDevice publishes the device tracker of a device.
+func (b *Bridge) discoverDevice(ctx context.Context, stateTopic string, d response.Device, force bool) error {
+	node, err := b.haNode(ctx)
+	if node == nil || err != nil {
+		return err
+	}
+
+	id := node.Identifiers[0] + "_" + DeviceID(d.PhysAddress)
+
+	name := d.Name
+	if name == "" {
+		name = d.PhysAddress
+	}
+
+	return b.publishState(ctx, b.discoveryTopic("device_tracker", DeviceID(d.PhysAddress)), b.entity(haEntity{
+		Name:                name,
+		UniqueID:            id,
+		StateTopic:          stateTopic,
+		ValueTemplate:       "{{ 'home' if value_json.Active else 'not_home' }}",
+		JSONAttributesTopic: stateTopic,
+		SourceType:          "router",
+		Device: haDevice{
+			Connections: [][2]string{{"mac", strings.ToLower(d.PhysAddress)}},
+			Name:        name,
+			ViaDevice:   node.Identifiers[0],
+		},
+	}), force)
+}
+
+// forgetDevice removes the device tracker of a device that was removed from
+// the Livebox.
+func (b *Bridge) forgetDevice(ctx context.Context, mac string) error {
+	// Nothing was published without the information of the Livebox.
+	if b.node == nil {
+		return nil
+	}
+
+	return b.clear(ctx, b.discoveryTopic("device_tracker", DeviceID(mac)))
+}
+
+// discoverWAN publishes the binary sensor of the WAN link.
+func (b *Bridge) discoverWAN(ctx context.Context, force bool) error {
+	node, err := b.haNode(ctx)
+	if node == nil || err != nil {
+		return err
+	}
+
+	return b.publishState(ctx, b.discoveryTopic("binary_sensor", "wan"), b.entity(haEntity{
+		Name:                "WAN",
+		UniqueID:            node.Identifiers[0] + "_wan",
+		StateTopic:          b.topics.WAN,
+		ValueTemplate:       "{{ 'ON' if value_json.LinkState == 'up' else 'OFF' }}",
+		JSONAttributesTopic: b.topics.WAN,
+		DeviceClass:         "connectivity",
+		Device:              *node,
+	}), force)
+}
+
+// discoverThroughput publishes the sensors of the throughput of an
+// interface.
+func (b *Bridge) discoverThroughput(ctx context.Context, stateTopic, intf string) error {
+	node, err := b.haNode(ctx)
+	if node == nil || err != nil {
+		return err
+	}
+
+	for _, direction := range []struct{ field, name, icon string }{
+		{"Rx", "received", "mdi:download"},
+		{"Tx", "sent", "mdi:upload"},
+	} {
+		objectID := intf + "_" + strings.ToLower(direction.field)
+
+		if err := b.publishState(ctx, b.discoveryTopic("sensor", objectID), b.entity(haEntity{
+			Name:              fmt.Sprintf("%s %s", intf, direction.name),
+			UniqueID:          node.Identifiers[0] + "_" + objectID,
+			StateTopic:        stateTopic,
+			ValueTemplate:     fmt.Sprintf("{{ value_json.%s | round(0) }}", direction.field),
+			DeviceClass:       "data_rate",
+			StateClass:        "measurement",
+			UnitOfMeasurement: "bit/s",
+			Icon:              direction.icon,
+			Device:            *node,
+		}), false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// haNode returns the Livebox in the discovery payloads, or nil if discovery
+// is disabled.
+func (b *Bridge) haNode(ctx context.Context) (*haDevice, error) {
+	if b.discoveryPrefix == "" || b.node != nil {
+		return b.node, nil
+	}
+
+	info, err := b.client.GetDeviceInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device info: %w", err)
+	}
+
+	id := "livebox"
+	if info.BaseMAC != "" {
+		id += "_" + DeviceID(info.BaseMAC)
+	}
+
+	b.node = &haDevice{
+		Identifiers:  []string{id},
+		Name:         "Livebox",
+		Manufacturer: info.Manufacturer,
+		Model:        info.ModelName,
+		SWVersion:    info.SoftwareVersion,
+		HWVersion:    info.HardwareVersion,
+	}
+
+	if info.BaseMAC != "" {
+		b.node.Connections = [][2]string{{"mac", strings.ToLower(info.BaseMAC)}}
+	}
+
+	return b.node, nil
+}
+
+// entity returns e, made unavailable when the bridge is offline.
+func (b *Bridge) entity(e haEntity) haEntity {
+	if b.topics.Availability != "" {
+		e.AvailabilityTopic = b.topics.Availability
+		e.PayloadAvailable = Online
+		e.PayloadNotAvailable = Offline
+	}
+
+	return e
+}
+
+// discoveryTopic returns the discovery topic of an entity of the Livebox.
+func (b *Bridge) discoveryTopic(component, objectID string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/config", b.discoveryPrefix, component, b.node.Identifiers[0], objectID)
+}
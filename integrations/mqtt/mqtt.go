@@ -0,0 +1,546 @@
+// Package mqtt publishes the state and the events of a Livebox to an MQTT
+// broker: the devices and whether they are connected, the status of the WAN
+// connection, the throughput of the interfaces and the calls. It makes it easy
+// to use the Livebox from home automation software such as Home Assistant
+// (see WithHomeAssistant) or Node-RED:
+//
+//	opts := paho.NewClientOptions().AddBroker("tcp://localhost:1883")
+//	topics := mqtt.DefaultTopics(mqtt.DefaultPrefix)
+//	opts.SetWill(topics.Availability, mqtt.Offline, 0, true)
+//
+//	mqttClient := paho.NewClient(opts)
+//	if token := mqttClient.Connect(); token.Wait() && token.Error() != nil {
+//		return token.Error()
+//	}
+//
+//	err := mqtt.NewBridge(client, mqttClient, mqtt.WithTopics(topics)).Run(ctx)
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// DefaultPrefix is the prefix of the default topics.
+const DefaultPrefix = "livebox"
+
+// DefaultResyncInterval is the default interval at which the whole state of
+// the Livebox is published again, in case events were missed.
+const DefaultResyncInterval = 5 * time.Minute
+
+// DefaultThroughputInterval is the default interval at which the throughput
+// of the interfaces is published.
+const DefaultThroughputInterval = 30 * time.Second
+
+// Payloads of the availability topic.
+const (
+	Online  = "online"
+	Offline = "offline"
+)
+
+// Topics are the topics the bridge publishes to. The {mac} placeholder is
+// replaced by the MAC address of a device, in lower case and without
+// separators, the {interface} placeholder by the name of an interface, and the
+// {handler} placeholder by the handler of an event, with its dots replaced by
+// slashes. Empty topics are not published.
+type Topics struct {
+	// Topic of the availability of the bridge, Online or Offline. Retained.
+	Availability string
+	// Topic of each device, the JSON encoding of a response.Device. Retained,
+	// it is cleared when the device is removed from the Livebox.
+	Device string
+	// Topic of the status of the WAN connection, the JSON encoding of a
+	// response.WANStatus. Retained.
+	WAN string
+	// Topic of the throughput of each interface of livebox.DefaultInterfaces,
+	// the JSON encoding of a Throughput.
+	Throughput string
+	// Topic of the calls, the JSON encoding of a response.Call is published
+	// at the end of each call.
+	Calls string
+	// Topic of the raw events received from the Livebox, their JSON encoding
+	// is published as is.
+	Events string
+}
+
+// DefaultTopics returns the default topics under prefix, the raw events are
+// not published.
+func DefaultTopics(prefix string) Topics {
+	return Topics{
+		Availability: prefix + "/status",
+		Device:       prefix + "/devices/{mac}",
+		WAN:          prefix + "/wan",
+		Throughput:   prefix + "/throughput/{interface}",
+		Calls:        prefix + "/calls",
+	}
+}
+
+// DeviceTopic returns the topic of the device with the given MAC address.
+func (t Topics) DeviceTopic(mac string) string {
+	return strings.ReplaceAll(t.Device, "{mac}", DeviceID(mac))
+}
+
+// ThroughputTopic returns the topic of the throughput of the given interface.
+func (t Topics) ThroughputTopic(intf string) string {
+	return strings.ReplaceAll(t.Throughput, "{interface}", intf)
+}
+
+// EventTopic returns the topic of the events with the given handler.
+func (t Topics) EventTopic(handler string) string {
+	return strings.ReplaceAll(t.Events, "{handler}", strings.ReplaceAll(handler, ".", "/"))
+}
+
+// DeviceID returns the identifier of a device in the topics: its MAC address
+// in lower case, without separators.
+func DeviceID(mac string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "").Replace(mac))
+}
+
+// Throughput is the throughput of an interface, in bits per second, averaged
+// since the previous reading. Rx is the traffic received by the Livebox on the
+// interface, Tx the traffic it sent: on the WAN interface, Rx is the download
+// throughput.
+type Throughput struct {
+	Rx float64 `json:"Rx"`
+	Tx float64 `json:"Tx"`
+}
+
+// Bridge publishes the state and the events of a Livebox to an MQTT broker.
+type Bridge struct {
+	client *livebox.Client
+	mqtt   paho.Client
+	topics Topics
+	qos    byte
+	resync time.Duration
+	// Interval at which the throughput is published.
+	throughput time.Duration
+	log        *slog.Logger
+	// Prefix of the Home Assistant discovery topics, discovery payloads are
+	// not published if empty.
+	discoveryPrefix string
+
+	// Payloads last published to the retained topics, by topic.
+	published map[string][]byte
+	// MAC addresses of the devices that were published, by topic.
+	devices map[string]string
+	// Counters of the interfaces at the previous reading, and its time.
+	counters     map[string]response.InterfaceCounters
+	countersTime time.Time
+	// The Livebox in the discovery payloads, nil until its information was
+	// received.
+	node *haDevice
+	// IDs of the calls already published, nil until the call list was
+	// received once.
+	calls map[string]struct{}
+}
+
+// Opt is a Bridge option.
+type Opt func(b *Bridge)
+
+// WithTopics sets the topics the bridge publishes to. If not used, the
+// topics returned by DefaultTopics(DefaultPrefix) are used.
+func WithTopics(topics Topics) Opt {
+	return func(b *Bridge) {
+		b.topics = topics
+	}
+}
+
+// WithQoS sets the quality of service of the published messages. If not
+// used, messages are published with QoS 0.
+func WithQoS(qos byte) Opt {
+	return func(b *Bridge) {
+		b.qos = qos
+	}
+}
+
+// WithResyncInterval sets the interval at which the whole state of the
+// Livebox is published again. If not used, DefaultResyncInterval is used.
+func WithResyncInterval(interval time.Duration) Opt {
+	return func(b *Bridge) {
+		b.resync = interval
+	}
+}
+
+// WithThroughputInterval sets the interval at which the throughput of the
+// interfaces is published. If not used, DefaultThroughputInterval is used.
+func WithThroughputInterval(interval time.Duration) Opt {
+	return func(b *Bridge) {
+		b.throughput = interval
+	}
+}
+
+// WithLogger attaches a logger to the bridge. Logging is disabled if unset.
+func WithLogger(log *slog.Logger) Opt {
+	return func(b *Bridge) {
+		b.log = log
+	}
+}
+
+// NewBridge returns a bridge that publishes the state and the events of the
+// Livebox of client with mqttClient, which must be connected.
+func NewBridge(client *livebox.Client, mqttClient paho.Client, opts ...Opt) *Bridge {
+	b := &Bridge{
+		client:     client,
+		mqtt:       mqttClient,
+		topics:     DefaultTopics(DefaultPrefix),
+		resync:     DefaultResyncInterval,
+		throughput: DefaultThroughputInterval,
+		published:  map[string][]byte{},
+		devices:    map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.resync <= 0 {
+		b.resync = DefaultResyncInterval
+	}
+
+	if b.throughput <= 0 {
+		b.throughput = DefaultThroughputInterval
+	}
+
+	if b.log == nil {
+		b.log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return b
+}
+
+// Run publishes the state of the Livebox, then publishes its changes and its
+// events until ctx is canceled. The availability topic is set to Online
+// while the bridge runs, and to Offline when it stops. Errors that occur
+// while the bridge runs are logged, they do not stop it. Run must not be
+// called concurrently.
+//
+// The state is published again when the event stream is reconnected if the
+// client was created with livebox.WithStreamStatusEvents, and periodically
+// otherwise, see WithResyncInterval.
+func (b *Bridge) Run(ctx context.Context) error {
+	var events []string
+	if b.topics.Events == "" {
+		events = []string{livebox.Wildcard(livebox.EventDevices), livebox.EventNMC, livebox.EventWAN, livebox.EventVoice}
+	}
+
+	// Watch events first, so that no change is missed.
+	l := b.client.Events(ctx, events)
+	defer l.Close()
+
+	if err := b.publish(ctx, b.topics.Availability, true, []byte(Online)); err != nil {
+		return err
+	}
+
+	b.sync(ctx, false)
+
+	ticker := time.NewTicker(b.resync)
+	defer ticker.Stop()
+
+	// The throughput is computed from the counters of two readings.
+	var throughput <-chan time.Time
+	if b.topics.Throughput != "" {
+		b.logError(ctx, b.publishThroughput(ctx))
+
+		t := time.NewTicker(b.throughput)
+		defer t.Stop()
+
+		throughput = t.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return b.stop()
+		case <-ticker.C:
+			b.sync(ctx, true)
+		case <-throughput:
+			b.logError(ctx, b.publishThroughput(ctx))
+		case ev, ok := <-l.C:
+			if !ok {
+				if ctx.Err() != nil {
+					return b.stop()
+				}
+
+				return l.Err()
+			}
+
+			b.handle(ctx, ev)
+		}
+	}
+}
+
+// stop publishes that the bridge is offline.
+func (b *Bridge) stop() error {
+	// The context of Run is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return b.publish(ctx, b.topics.Availability, true, []byte(Offline))
+}
+
+// handle publishes an event and the state it changed.
+func (b *Bridge) handle(ctx context.Context, ev *response.Event) {
+	if ev.Status != nil && ev.Status.State == response.StreamReconnected {
+		// Events may have been lost while the stream was disconnected.
+		b.sync(ctx, false)
+		return
+	}
+
+	if ev.Error != nil {
+		b.log.WarnContext(ctx, "Event stream error", slog.Any("error", ev.Error))
+		return
+	}
+
+	if ev.Event == nil {
+		return
+	}
+
+	handler := ev.Event.Handler
+
+	if b.topics.Events != "" {
+		b.logError(ctx, b.publish(ctx, b.topics.EventTopic(handler), false, ev.Raw))
+	}
+
+	switch {
+	case under(handler, livebox.EventDevices):
+		b.logError(ctx, b.publishDevices(ctx, false))
+	case under(handler, livebox.EventNMC), under(handler, livebox.EventWAN):
+		b.logError(ctx, b.publishWAN(ctx, false))
+	case under(handler, livebox.EventVoice):
+		b.logError(ctx, b.publishCalls(ctx))
+	}
+}
+
+// sync publishes the whole state of the Livebox. Unless force is true, the
+// retained topics whose payload did not change are not published again.
+func (b *Bridge) sync(ctx context.Context, force bool) {
+	b.logError(ctx, b.publishDevices(ctx, force))
+	b.logError(ctx, b.publishWAN(ctx, force))
+	b.logError(ctx, b.publishCalls(ctx))
+}
+
+// publishDevices publishes the devices, and clears the topics of the devices
+// that were removed.
+func (b *Bridge) publishDevices(ctx context.Context, force bool) error {
+	if b.topics.Device == "" {
+		return nil
+	}
+
+	devices, err := b.client.Devices().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	current := make(map[string]string, len(devices))
+	for _, d := range devices {
+		topic := b.topics.DeviceTopic(d.PhysAddress)
+		current[topic] = d.PhysAddress
+
+		if err := b.publishState(ctx, topic, d, force); err != nil {
+			return err
+		}
+
+		if err := b.discoverDevice(ctx, topic, d, force); err != nil {
+			return err
+		}
+	}
+
+	for topic, mac := range b.devices {
+		if _, ok := current[topic]; ok {
+			continue
+		}
+
+		if err := b.clear(ctx, topic); err != nil {
+			return err
+		}
+
+		if err := b.forgetDevice(ctx, mac); err != nil {
+			return err
+		}
+	}
+
+	b.devices = current
+
+	return nil
+}
+
+// publishWAN publishes the status of the WAN connection.
+func (b *Bridge) publishWAN(ctx context.Context, force bool) error {
+	if b.topics.WAN == "" {
+		return nil
+	}
+
+	status, err := b.client.GetWANStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get WAN status: %w", err)
+	}
+
+	if err := b.publishState(ctx, b.topics.WAN, status, force); err != nil {
+		return err
+	}
+
+	return b.discoverWAN(ctx, force)
+}
+
+// publishThroughput publishes the throughput of the interfaces since the
+// previous reading of their counters.
+func (b *Bridge) publishThroughput(ctx context.Context) error {
+	counters, err := b.client.GetInterfaceCounters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get interface counters: %w", err)
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(b.countersTime).Seconds()
+
+	current := make(map[string]response.InterfaceCounters, len(counters))
+	for _, c := range counters {
+		current[c.Interface] = c
+
+		prev, ok := b.counters[c.Interface]
+		// Counters that were reset have no throughput.
+		if !ok || c.RxBytes < prev.RxBytes || c.TxBytes < prev.TxBytes {
+			continue
+		}
+
+		topic := b.topics.ThroughputTopic(c.Interface)
+		payload, err := json.Marshal(Throughput{
+			Rx: float64(c.RxBytes-prev.RxBytes) * 8 / elapsed,
+			Tx: float64(c.TxBytes-prev.TxBytes) * 8 / elapsed,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := b.publish(ctx, topic, false, payload); err != nil {
+			return err
+		}
+
+		if err := b.discoverThroughput(ctx, topic, c.Interface); err != nil {
+			return err
+		}
+	}
+
+	b.counters = current
+	b.countersTime = now
+
+	return nil
+}
+
+// publishCalls publishes the calls that were not published yet. The calls of
+// the list received the first time are not published.
+func (b *Bridge) publishCalls(ctx context.Context) error {
+	if b.topics.Calls == "" {
+		return nil
+	}
+
+	calls, err := b.client.GetCallList(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get calls: %w", err)
+	}
+
+	seed := b.calls == nil
+	if seed {
+		b.calls = make(map[string]struct{}, len(calls))
+	}
+
+	for _, call := range calls {
+		if _, ok := b.calls[call.ID]; ok {
+			continue
+		}
+
+		b.calls[call.ID] = struct{}{}
+
+		if seed {
+			continue
+		}
+
+		payload, err := json.Marshal(call)
+		if err != nil {
+			return err
+		}
+
+		if err := b.publish(ctx, b.topics.Calls, false, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishState publishes the JSON encoding of v to a retained topic, unless
+// it did not change since it was last published and force is false.
+func (b *Bridge) publishState(ctx context.Context, topic string, v any, force bool) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if prev, ok := b.published[topic]; ok && !force && bytes.Equal(prev, payload) {
+		return nil
+	}
+
+	if err := b.publish(ctx, topic, true, payload); err != nil {
+		return err
+	}
+
+	b.published[topic] = payload
+
+	return nil
+}
+
+// clear removes the retained message of a topic, with an empty retained
+// message.
+func (b *Bridge) clear(ctx context.Context, topic string) error {
+	if err := b.publish(ctx, topic, true, nil); err != nil {
+		return err
+	}
+
+	delete(b.published, topic)
+
+	return nil
+}
+
+// publish publishes a message and waits until it is sent.
+func (b *Bridge) publish(ctx context.Context, topic string, retained bool, payload []byte) error {
+	if topic == "" {
+		return nil
+	}
+
+	token := b.mqtt.Publish(topic, b.qos, retained, payload)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-token.Done():
+	}
+
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+// logError logs err, if not nil.
+func (b *Bridge) logError(ctx context.Context, err error) {
+	if err != nil && ctx.Err() == nil {
+		b.log.WarnContext(ctx, "Failed to publish the state of the Livebox", slog.Any("error", err))
+	}
+}
+
+// under returns true if handler is the handler of object or of one of its
+// children.
+func under(handler, object string) bool {
+	return handler == object || strings.HasPrefix(handler, object+".")
+}
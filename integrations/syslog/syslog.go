@@ -0,0 +1,404 @@
+// Package syslog forwards the events of a Livebox to a syslog server, as RFC
+// 5424 messages, so that they land in the same log pipelines as the logs of
+// the rest of the network:
+//
+//	f := syslog.NewForwarder(client, "udp", "logs.example.com:514")
+//	err := f.Run(ctx)
+//
+// The severity of each message depends on the event (see DefaultSeverity):
+// for instance, a WAN link going down is an error, a new device is a notice.
+// Messages carry the handler and the reason of the event as structured data,
+// and a readable summary with the attributes of the event.
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// Severity is the severity of a syslog message.
+type Severity int
+
+// Severities defined by RFC 5424.
+const (
+	Emergency Severity = iota
+	Alert
+	Critical
+	Error
+	Warning
+	Notice
+	Informational
+	Debug
+)
+
+// Facility is the facility of a syslog message.
+type Facility int
+
+// Facilities defined by RFC 5424 that are suitable for the events of a
+// Livebox.
+const (
+	User   Facility = 1
+	Daemon Facility = 3
+)
+
+// Facilities reserved for local use.
+const (
+	Local0 Facility = 16 + iota
+	Local1
+	Local2
+	Local3
+	Local4
+	Local5
+	Local6
+	Local7
+)
+
+// DefaultAppName is the default APP-NAME of the messages.
+const DefaultAppName = "livebox"
+
+// StructuredDataID is the ID of the structured data element of the messages,
+// its parameters are the handler and the reason of the event. 32473 is the
+// enterprise number reserved for examples by RFC 5612.
+const StructuredDataID = "event@32473"
+
+// Write timeout of the messages sent over TCP.
+const writeTimeout = 10 * time.Second
+
+// SeverityFunc returns the severity of the message of an event.
+type SeverityFunc func(ev *response.Event) Severity
+
+// DefaultSeverity returns the severity of an event:
+//   - Error for a WAN link that goes down, and for errors of the event stream,
+//   - Warning when the event stream is disconnected,
+//   - Notice for other changes of the WAN connection, devices that are added
+//     or removed, and when the event stream is reconnected,
+//   - Informational for other events.
+func DefaultSeverity(ev *response.Event) Severity {
+	switch {
+	case ev.Status != nil && ev.Status.State == response.StreamDisconnected:
+		return Warning
+	case ev.Status != nil:
+		return Notice
+	case ev.Error != nil:
+		return Error
+	case ev.Event == nil:
+		return Debug
+	}
+
+	attrs := ev.Event.Object.Attributes
+
+	switch {
+	case under(ev.Event.Handler, livebox.EventWAN) || under(ev.Event.Handler, livebox.EventNMC):
+		if state, _ := attrs["LinkState"].(string); state == "down" {
+			return Error
+		}
+
+		if _, ok := attrs["LinkState"]; ok {
+			return Notice
+		}
+
+		if _, ok := attrs["ConnectionState"]; ok {
+			return Notice
+		}
+	case ev.Event.Object.Reason == "device_added", ev.Event.Object.Reason == "device_deleted":
+		return Notice
+	}
+
+	return Informational
+}
+
+// under returns true if handler is object or one of its children.
+func under(handler, object string) bool {
+	return handler == object || strings.HasPrefix(handler, object+".")
+}
+
+// Forwarder forwards the events of a Livebox to a syslog server.
+type Forwarder struct {
+	client   *livebox.Client
+	network  string
+	address  string
+	tls      *tls.Config
+	events   []string
+	facility Facility
+	hostname string
+	appName  string
+	severity SeverityFunc
+	log      *slog.Logger
+
+	// Connection to the syslog server, nil when disconnected.
+	conn net.Conn
+}
+
+// Opt is a Forwarder option.
+type Opt func(f *Forwarder)
+
+// WithEvents sets the objects whose events are forwarded, such as
+// livebox.EventDevices. If not used, all the events are forwarded.
+func WithEvents(events []string) Opt {
+	return func(f *Forwarder) {
+		f.events = events
+	}
+}
+
+// WithFacility sets the facility of the messages. If not used, Daemon is
+// used.
+func WithFacility(facility Facility) Opt {
+	return func(f *Forwarder) {
+		f.facility = facility
+	}
+}
+
+// WithHostname sets the HOSTNAME of the messages. If not used, the host name
+// reported by the kernel is used.
+func WithHostname(hostname string) Opt {
+	return func(f *Forwarder) {
+		f.hostname = hostname
+	}
+}
+
+// WithAppName sets the APP-NAME of the messages. If not used, DefaultAppName
+// is used.
+func WithAppName(appName string) Opt {
+	return func(f *Forwarder) {
+		f.appName = appName
+	}
+}
+
+// WithSeverity sets the function that returns the severity of the messages.
+// If not used, DefaultSeverity is used.
+func WithSeverity(severity SeverityFunc) Opt {
+	return func(f *Forwarder) {
+		f.severity = severity
+	}
+}
+
+// WithTLS sends the messages over TLS, as described by RFC 5425. The network
+// of the forwarder must be "tcp", "tcp4" or "tcp6".
+func WithTLS(config *tls.Config) Opt {
+	return func(f *Forwarder) {
+		f.tls = config
+	}
+}
+
+// WithLogger attaches a logger to the forwarder, messages that cannot be sent
+// are logged. Logging is disabled if unset.
+func WithLogger(log *slog.Logger) Opt {
+	return func(f *Forwarder) {
+		f.log = log
+	}
+}
+
+// NewForwarder returns a forwarder that sends the events of the Livebox of
+// client to the syslog server at address. The network is "udp", "tcp" or
+// "unix" (and their variants supported by net.Dial): with stream networks,
+// messages are framed with octet counting, as described by RFC 6587.
+func NewForwarder(client *livebox.Client, network, address string, opts ...Opt) *Forwarder {
+	f := &Forwarder{
+		client:   client,
+		network:  network,
+		address:  address,
+		facility: Daemon,
+		appName:  DefaultAppName,
+		severity: DefaultSeverity,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if f.hostname == "" {
+		f.hostname, _ = os.Hostname()
+	}
+
+	if f.log == nil {
+		f.log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return f
+}
+
+// Run forwards the events until ctx is canceled, or until the event stream
+// fails permanently. It returns an error if the syslog server cannot be
+// reached at startup. Later, messages that cannot be sent are dropped, and
+// the connection is reopened for the next message.
+func (f *Forwarder) Run(ctx context.Context) error {
+	if err := f.dial(ctx); err != nil {
+		return err
+	}
+
+	defer f.close()
+
+	l := f.client.Events(ctx, f.events)
+	defer l.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-l.C:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				return l.Err()
+			}
+
+			if err := f.send(ctx, f.format(time.Now(), ev)); err != nil {
+				f.log.WarnContext(ctx, "Failed to forward event", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// send sends a message, the connection is reopened once if it fails.
+func (f *Forwarder) send(ctx context.Context, msg []byte) error {
+	if f.stream() {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+
+	var err error
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if f.conn == nil {
+			if err = f.dial(ctx); err != nil {
+				continue
+			}
+		}
+
+		if f.stream() {
+			_ = f.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		}
+
+		if _, err = f.conn.Write(msg); err == nil {
+			return nil
+		}
+
+		f.close()
+	}
+
+	return err
+}
+
+// dial opens the connection to the syslog server.
+func (f *Forwarder) dial(ctx context.Context) error {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	if f.tls != nil {
+		d := &tls.Dialer{Config: f.tls}
+		conn, err = d.DialContext(ctx, f.network, f.address)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, f.network, f.address)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog server: %w", err)
+	}
+
+	f.conn = conn
+
+	return nil
+}
+
+// close closes the connection to the syslog server.
+func (f *Forwarder) close() {
+	if f.conn != nil {
+		_ = f.conn.Close()
+		f.conn = nil
+	}
+}
+
+// stream returns true if messages are sent over a stream connection and must
+// be framed.
+func (f *Forwarder) stream() bool {
+	switch f.network {
+	case "udp", "udp4", "udp6", "unixgram":
+		return false
+	default:
+		return true
+	}
+}
+
+// format returns the RFC 5424 message of an event received at t.
+func (f *Forwarder) format(t time.Time, ev *response.Event) []byte {
+	var (
+		msgID = "event"
+		sd    = "-"
+		text  string
+	)
+
+	switch {
+	case ev.Status != nil:
+		msgID = "stream_" + ev.Status.State.String()
+		text = "Event stream " + ev.Status.State.String()
+
+		if ev.Error != nil {
+			text += ": " + ev.Error.Error()
+		}
+	case ev.Error != nil:
+		msgID = "error"
+		text = "Event error: " + ev.Error.Error()
+	case ev.Event != nil:
+		if ev.Event.Object.Reason != "" {
+			msgID = ev.Event.Object.Reason
+		}
+
+		sd = fmt.Sprintf(`[%s handler="%s" reason="%s"]`, StructuredDataID,
+			sdEscaper.Replace(ev.Event.Handler), sdEscaper.Replace(ev.Event.Object.Reason))
+
+		text = strings.TrimSpace(ev.Event.Handler + " " + ev.Event.Object.Reason)
+
+		if len(ev.Event.Object.Attributes) > 0 {
+			if attrs, err := json.Marshal(ev.Event.Object.Attributes); err == nil {
+				text += " " + string(attrs)
+			}
+		}
+	}
+
+	pri := int(f.facility)*8 + int(f.severity(ev))
+
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s - %s %s %s", pri,
+		t.Format("2006-01-02T15:04:05.000000Z07:00"),
+		header(f.hostname, 255), header(f.appName, 48), header(msgID, 32), sd, text))
+}
+
+// sdEscaper escapes the values of the parameters of structured data.
+var sdEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// header returns a field of the header of a message: printable ASCII
+// characters, at most n of them, or "-" if empty.
+func header(s string, n int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+
+		return r
+	}, s)
+
+	if len(s) > n {
+		s = s[:n]
+	}
+
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,34 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+)
+
+// RoundTripFunc sends a request to the Livebox API and unmarshals the response
+// into out.
+type RoundTripFunc func(ctx context.Context, req *request.Request, out any, opts ...request.Option) error
+
+// Interceptor wraps the RoundTripFunc that sends API requests. It can be used
+// to log, measure, cache or modify requests and responses. An interceptor
+// must call next to send the request, unless it can respond by itself.
+type Interceptor func(next RoundTripFunc) RoundTripFunc
+
+// WithInterceptor adds an interceptor invoked around every request sent with
+// Client.Request and the typed methods. Interceptors are invoked in the order
+// they were added: the first one is the outermost.
+func WithInterceptor(interceptor Interceptor) Opt {
+	return func(c *clientOpts) {
+		c.interceptors = append(c.interceptors, interceptor)
+	}
+}
+
+// chain returns the RoundTripFunc that invokes the interceptors around send.
+func chain(send RoundTripFunc, interceptors []Interceptor) RoundTripFunc {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		send = interceptors[i](send)
+	}
+
+	return send
+}
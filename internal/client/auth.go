@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Time during which a failed login is not attempted again for the same
+// session, the error of the failed login is returned instead.
+const loginCooldown = 2 * time.Second
+
+// authenticator makes sure that a single login is running at a time. Requests
+// that need to authenticate while a login is running wait for it and share
+// its result.
+type authenticator struct {
+	mu sync.Mutex
+	// Login in progress, nil if there is none.
+	flight *authFlight
+	// Last failed login.
+	failedErr     error
+	failedAt      time.Time
+	failedVersion uint64
+}
+
+// authFlight is a login in progress.
+type authFlight struct {
+	done chan struct{}
+	err  error
+}
+
+// authenticate creates a new session if the version of the current session
+// is still currentVersion. The first return value is false if the session was
+// renewed by another caller in the meantime, the request can then be sent
+// again with the new session.
+func (c *Client) authenticate(ctx context.Context, currentVersion uint64) (bool, error) {
+	a := &c.auth
+
+	for {
+		a.mu.Lock()
+
+		if _, _, v := c.session.GetCredentials(); v != currentVersion {
+			a.mu.Unlock()
+			return false, nil
+		}
+
+		if a.failedErr != nil && a.failedVersion == currentVersion && time.Since(a.failedAt) < loginCooldown {
+			err := a.failedErr
+			a.mu.Unlock()
+
+			return true, err
+		}
+
+		f := a.flight
+		if f == nil {
+			break
+		}
+
+		// Wait for the login in progress.
+		a.mu.Unlock()
+
+		select {
+		case <-f.done:
+			// The login was interrupted by its caller, try again.
+			if isContextError(f.err) {
+				continue
+			}
+
+			if f.err != nil {
+				return true, f.err
+			}
+
+			return false, nil
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+
+	f := &authFlight{done: make(chan struct{})}
+	a.flight = f
+	a.mu.Unlock()
+
+	f.err = c.createSession(ctx)
+
+	a.mu.Lock()
+	a.flight = nil
+	// Logins interrupted by the caller are not failures of the Livebox.
+	if f.err != nil && !isContextError(f.err) {
+		a.failedErr = f.err
+		a.failedAt = time.Now()
+		a.failedVersion = currentVersion
+	} else {
+		a.failedErr = nil
+	}
+	a.mu.Unlock()
+
+	close(f.done)
+
+	return true, f.err
+}
+
+// isContextError returns true if err is caused by a canceled context or an
+// exceeded deadline.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
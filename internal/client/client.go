@@ -9,8 +9,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"path"
+	"reflect"
 	"strings"
-	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Tomy2e/livebox-api-client/api/request"
 	"github.com/Tomy2e/livebox-api-client/api/response"
@@ -19,6 +22,9 @@ import (
 const (
 	// All API requests are sent to this endpoint using the POST method.
 	apiEndpoint = "ws"
+	// Objects of the datamodel can be retrieved from this endpoint using the
+	// GET method.
+	sysbusEndpoint = "sysbus"
 	// Value of the Authorization HTTP Header during the login request.
 	authorizationHeaderLogin = "X-Sah-Login"
 	// Suffix of the name of the cookie that contains the session ID. The
@@ -30,6 +36,8 @@ const (
 	// use non-compliant names. We need to replace the non-compliant cookie name
 	// with a compliant cookie name.
 	patchedSessidCookieNameSuffix = "_sessid"
+	// Maximum size of the body of an error response that is read.
+	maxErrorBodySize = 64 << 10
 )
 
 var (
@@ -46,8 +54,37 @@ var (
 	ErrEmptySessidCookie = errors.New("did not receive sessid cookie")
 	// ErrStatusError is returned if an unexpected status code was received.
 	ErrStatusError = errors.New("status error")
+	// ErrInsufficientPermissions is returned when a request is denied while
+	// the session is valid, because the user is not allowed to send it.
+	ErrInsufficientPermissions = errors.New("insufficient permissions")
 )
 
+// StatusError is returned if an unexpected status code was received. It
+// matches ErrStatusError.
+type StatusError struct {
+	StatusCode int
+	// Error found in the body of the response, nil if there is none.
+	Err error
+}
+
+func (e *StatusError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: got %d, expected 200: %s", ErrStatusError, e.StatusCode, e.Err)
+	}
+
+	return fmt.Sprintf("%s: got %d, expected 200", ErrStatusError, e.StatusCode)
+}
+
+// Unwrap returns the error found in the body of the response.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if target is ErrStatusError.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrStatusError
+}
+
 type ContentType string
 
 const (
@@ -62,20 +99,40 @@ const (
 type Client struct {
 	// HTTP client to use to send requests.
 	client *http.Client
+	// HTTP client to use to send event requests.
+	eventClient *http.Client
 	// Address where to send API requests.
 	address string
-	// Livebox username.
-	username string
-	// Livebox password.
-	password string
+	// Address of the sysbus REST endpoint.
+	sysbusAddress string
+	// Returns the Livebox username and password, called before each
+	// authentication.
+	credentials CredentialsFunc
 	// Session data.
 	session session
 	// Makes sure there is at most one authentication attempt running in parallel.
-	mu sync.Mutex
+	auth authenticator
+	// Number of successful authentications.
+	authentications atomic.Uint64
+	// API version used to communicate with the Livebox.
+	apiVersion atomic.Int32
+	// Time of the last successful request, in nanoseconds since the epoch.
+	lastActivity atomic.Int64
+	// Shortest idle duration after which the session expired, 0 if unknown.
+	sessionLifetime atomic.Int64
+	// Called after a new session is created, may be nil.
+	onSession func()
+	// Request that any user is allowed to send, used to check whether a
+	// session is still valid. May be nil.
+	sessionProbe any
 }
 
-// New returns a new low level client.
-func New(client *http.Client, address, username, password string) (*Client, error) {
+// CredentialsFunc returns the username and password used to authenticate.
+type CredentialsFunc func(ctx context.Context) (username, password string, err error)
+
+// New returns a new low level client. The API endpoints are located under the
+// path of the address, followed by basePath.
+func New(client *http.Client, address, basePath string, credentials CredentialsFunc) (*Client, error) {
 	if client == nil {
 		client = http.DefaultClient
 	}
@@ -85,71 +142,185 @@ func New(client *http.Client, address, username, password string) (*Client, erro
 		return nil, fmt.Errorf("failed to parse livebox address: %w", err)
 	}
 
-	u.Path = apiEndpoint
+	prefix := path.Join("/", u.Path, basePath)
+
+	u.Path = path.Join(prefix, apiEndpoint)
+	address = u.String()
+
+	u.Path = path.Join(prefix, sysbusEndpoint)
 
 	return &Client{
-		client:   client,
-		address:  u.String(),
-		username: username,
-		password: password,
+		client:        client,
+		eventClient:   client,
+		address:       address,
+		sysbusAddress: u.String(),
+		credentials:   credentials,
 	}, nil
 }
 
 // Request sends a request with the provided contentType. The "in" object will be
 // marshalled to json. The response will be unmarshalled into the "out" object.
-func (c *Client) Request(ctx context.Context, contentType ContentType, in, out any) error {
-	// Authenticate the first request.
-	if _, _, v := c.session.GetCredentials(); v == 0 {
-		if _, err := c.authenticate(ctx, v); err != nil {
-			return err
+// The Timeout field of the options is ignored.
+func (c *Client) Request(ctx context.Context, contentType ContentType, in, out any, opts ...request.Option) error {
+	o := request.NewOptions(opts)
+	if o.ContentType != "" {
+		contentType = ContentType(o.ContentType)
+	}
+
+	err := c.do(ctx, !o.NoAutoReauth, func(authorization string) (*http.Request, error) {
+		req, err := c.newCallRequest(ctx, contentType, in, authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range o.Header {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
 		}
+
+		return req, nil
+	}, out)
+
+	// Event requests do not prevent the session from expiring.
+	if err == nil && contentType != ContentTypeEvent {
+		c.lastActivity.Store(time.Now().UnixNano())
+	}
+
+	return err
+}
+
+// newCallRequest returns the HTTP request that sends in with the provided
+// contentType. The API version is only known once authenticated, the request
+// must be created for each attempt.
+func (c *Client) newCallRequest(ctx context.Context, contentType ContentType, in any, authorization string) (*http.Request, error) {
+	if apiReq, ok := in.(*request.Request); ok && c.APIVersion() == APIVersion1 && contentType == ContentTypeWS {
+		return c.newLegacyRequest(ctx, apiReq, authorization)
 	}
 
-	// Create request payload
 	payload, err := json.Marshal(in)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return newRequest(ctx, contentType, c.address, bytes.NewReader(payload), authorization)
+}
+
+// Get retrieves an object of the datamodel from the sysbus REST endpoint. The
+// path is the path of the object, using "/" as a separator. The response will
+// be unmarshalled into the "out" object.
+func (c *Client) Get(ctx context.Context, path string, query url.Values, out any) error {
+	u := c.sysbusAddress + "/" + strings.TrimPrefix(path, "/")
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	return c.do(ctx, true, func(authorization string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("x-context", strings.Split(authorization, " ")[1])
+
+		return req, nil
+	}, out)
+}
+
+// do sends the HTTP request created by newReq with the credentials of the
+// current session. The client authenticates if there is no session yet, and
+// reauthenticates once if the session is expired and reauth is true. The newReq
+// function may be called several times, it must set the given authorization on
+// the request.
+func (c *Client) do(ctx context.Context, reauth bool, newReq func(authorization string) (*http.Request, error), out any) error {
+	// Authenticate the first request.
+	if _, _, v := c.session.GetCredentials(); v == 0 {
+		if _, err := c.authenticate(ctx, v); err != nil {
+			return err
+		}
 	}
 
 	authAttempted := false
 
+	// Duration without activity of the session that was rejected. It is only
+	// recorded as the lifetime of sessions once the request succeeds with a
+	// new session, as the rejection may not be caused by an expiration.
+	var idle time.Duration
+
 	for {
-		// Create HTTP request with request payload
-		r, v, err := c.newAuthenticatedRequest(ctx, contentType, bytes.NewReader(payload))
+		// Create HTTP request with the current credentials
+		r, v, err := c.newAuthenticatedRequest(newReq)
 		if err != nil {
 			return err
 		}
 
 		if _, err := c.doRequest(r, out); err != nil { //nolint:bodyclose // Already closed.
-			// If reauthentication was already attempted, return error now.
-			if authAttempted {
+			// Check if the server returned a permission denied error.
+			if !response.IsPermissionDeniedError(err) {
 				return err
 			}
 
-			// Check if the server returned a permission denied error.
-			if response.IsPermissionDeniedError(err) {
-				// Try to renew the session if the version of the session that
-				// was used is still the current one.
-				if authAttempted, err = c.authenticate(ctx, v); err != nil {
-					return err
-				}
-
-				continue
+			// The session was just created, or it is still accepted by
+			// the Livebox, so it is valid: the user is not allowed to send
+			// this request and a new session would not help.
+			if authAttempted || c.session.IsFresh(v) || c.sessionValid(ctx, v) {
+				return fmt.Errorf("%w: %w", ErrInsufficientPermissions, err)
 			}
 
-			return err
+			idle = c.idleDuration()
+
+			// Return the error now if reauthentication is disabled.
+			if !reauth {
+				return err
+			}
+
+			// Try to renew the session if the version of the session that
+			// was used is still the current one.
+			if authAttempted, err = c.authenticate(ctx, v); err != nil {
+				return err
+			}
+
+			continue
 		}
 
 		break
 	}
 
+	// The new session was accepted, the previous one had expired.
+	if idle > 0 {
+		c.observeExpiredSession(idle)
+	}
+
 	return nil
 }
 
-func (c *Client) newAuthenticatedRequest(ctx context.Context, contentType ContentType, body io.Reader) (*http.Request, uint64, error) {
+// sessionValid returns true if the session of version v is the current one and
+// is still accepted by the Livebox, by sending the session probe with it.
+func (c *Client) sessionValid(ctx context.Context, v uint64) bool {
+	if c.sessionProbe == nil {
+		return false
+	}
+
+	r, version, err := c.newAuthenticatedRequest(func(authorization string) (*http.Request, error) {
+		return c.newCallRequest(ctx, ContentTypeWS, c.sessionProbe, authorization)
+	})
+	if err != nil || version != v {
+		return false
+	}
+
+	if _, err := c.doRequest(r, &json.RawMessage{}); err != nil { //nolint:bodyclose // Already closed.
+		return false
+	}
+
+	c.lastActivity.Store(time.Now().UnixNano())
+
+	return true
+}
+
+func (c *Client) newAuthenticatedRequest(newReq func(authorization string) (*http.Request, error)) (*http.Request, uint64, error) {
 	authorization, cookie, version := c.session.GetCredentials()
 
-	req, err := newRequest(ctx, contentType, c.address, body, authorization)
+	req, err := newReq(authorization)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -166,14 +337,22 @@ func (c *Client) newAuthenticatedRequest(ctx context.Context, contentType Conten
 // error is found in the body of the request. The HTTP response is returned,
 // its body is already closed.
 func (c *Client) doRequest(req *http.Request, out interface{}) (*http.Response, error) {
-	res, err := c.client.Do(req)
+	client := c.client
+	if req.Header.Get("Content-Type") == string(ContentTypeEvent) {
+		client = c.eventClient
+	}
+
+	res, err := client.Do(req)
 	if err != nil {
 		return res, err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return res, fmt.Errorf("%w: got %d, expected 200", ErrStatusError, res.StatusCode)
+		// The body may describe the error, it is ignored if it cannot be read.
+		b, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+
+		return res, &StatusError{StatusCode: res.StatusCode, Err: handleRequestError(b)}
 	}
 
 	b, err := io.ReadAll(res.Body)
@@ -184,63 +363,258 @@ func (c *Client) doRequest(req *http.Request, out interface{}) (*http.Response,
 	// Fix for some event requests that contain a trailing "null" string.
 	b = bytes.TrimSuffix(b, []byte("null"))
 
-	if err := handleRequestError(b); err != nil {
+	if err := decodeResponse(b, out, isLegacyCall(req)); err != nil {
 		return res, err
 	}
 
-	// No error, we can unmarshal the response body into the "out" parameter.
-	if err := json.Unmarshal(b, out); err != nil {
-		return res, err
+	return res, nil
+}
+
+// decodeResponse unmarshals the body of a response into out, or returns the
+// error it describes. The body is only decoded a second time, to look for an
+// error, if decoding it into out failed or did not set anything: error
+// responses do not have a result.
+func decodeResponse(b []byte, out any, legacy bool) error {
+	target := out
+	// Responses of the legacy API are wrapped in a "result" object.
+	if legacy {
+		target = &legacyResponse{Result: out}
 	}
 
-	return res, nil
+	if err := json.Unmarshal(b, target); err != nil {
+		if apiErr := handleRequestError(b); apiErr != nil {
+			return apiErr
+		}
+
+		return err
+	}
+
+	if mayHideError(out) {
+		return handleRequestError(b)
+	}
+
+	return nil
 }
 
-func (c *Client) authenticate(ctx context.Context, currentVersion uint64) (bool, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// mayHideError returns true if out may have been decoded from an error
+// response: it is empty, or it accepts any field.
+func mayHideError(out any) bool {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return true
+	}
 
-	if _, _, v := c.session.GetCredentials(); v != currentVersion {
-		return false, nil
+	switch v = v.Elem(); v.Kind() {
+	case reflect.Interface, reflect.Map, reflect.Slice:
+		return true
+	default:
+		return v.IsZero()
 	}
+}
 
-	// Create payload
-	payload, err := json.Marshal(request.NewLogin(c.username, c.password))
-	if err != nil {
-		return true, err
+// SessionInfo returns information about the current session. The second
+// return value is false if the client is not authenticated yet.
+func (c *Client) SessionInfo() (SessionInfo, bool) {
+	return c.session.Info()
+}
+
+// Refresh renews the current session, or creates one if the client is not
+// authenticated yet.
+func (c *Client) Refresh(ctx context.Context) error {
+	_, _, v := c.session.GetCredentials()
+	_, err := c.authenticate(ctx, v)
+
+	return err
+}
+
+// SessionState returns the state of the current session, so that it can be
+// restored later with RestoreSession. The second return value is false if the
+// client is not authenticated yet.
+func (c *Client) SessionState() (SessionState, bool) {
+	state, ok := c.session.State()
+	state.APIVersion = c.APIVersion()
+
+	return state, ok
+}
+
+// RestoreSession restores a session saved with SessionState. The client
+// authenticates again if the session expired in the meantime.
+func (c *Client) RestoreSession(state SessionState) {
+	if state.APIVersion != APIVersionAuto {
+		c.SetAPIVersion(state.APIVersion)
+	}
+
+	c.session.Restore(state)
+}
+
+// OnSession sets a function that is called after a new session is created.
+// It must be called before the client is used.
+func (c *Client) OnSession(f func()) {
+	c.onSession = f
+}
+
+// SetSessionProbe sets a request that any user is allowed to send. When a
+// request is denied, it is sent with the same session to check whether the
+// session expired, or whether the user is not allowed to send the request. If
+// not set, the session is assumed to be expired unless it was just created.
+// It must be called before the client is used.
+func (c *Client) SetSessionProbe(probe any) {
+	c.sessionProbe = probe
+}
+
+// SetEventHTTPClient sets the HTTP client used to send event requests, which
+// are long-polling requests. If not set, the HTTP client of the client is
+// used. It must be called before the client is used.
+func (c *Client) SetEventHTTPClient(client *http.Client) {
+	if client != nil {
+		c.eventClient = client
+	}
+}
+
+// CloseIdleConnections closes the idle connections of the HTTP clients.
+func (c *Client) CloseIdleConnections() {
+	c.client.CloseIdleConnections()
+
+	if c.eventClient != c.client {
+		c.eventClient.CloseIdleConnections()
+	}
+}
+
+// CloseIdleEventConnections closes the idle connections of the HTTP client of
+// the event requests, if it is not the HTTP client of the other requests.
+func (c *Client) CloseIdleEventConnections() {
+	if c.eventClient != c.client {
+		c.eventClient.CloseIdleConnections()
+	}
+}
+
+// LastActivity returns the time of the last successful request, other than
+// event requests. It is zero if no request succeeded yet.
+func (c *Client) LastActivity() time.Time {
+	if ns := c.lastActivity.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+
+	return time.Time{}
+}
+
+// SessionLifetime returns the shortest duration without activity after which
+// a session was found expired, 0 if no session expired yet.
+func (c *Client) SessionLifetime() time.Duration {
+	return time.Duration(c.sessionLifetime.Load())
+}
+
+// idleDuration returns the duration since the last successful request, 0 if
+// no request succeeded yet.
+func (c *Client) idleDuration() time.Duration {
+	last := c.lastActivity.Load()
+	if last == 0 {
+		return 0
 	}
 
-	// Create request and send it
-	req, err := newRequest(ctx, ContentTypeWS, c.address, bytes.NewReader(payload), authorizationHeaderLogin)
+	return time.Since(time.Unix(0, last))
+}
+
+// observeExpiredSession records the duration without activity after which a
+// session expired.
+func (c *Client) observeExpiredSession(idle time.Duration) {
+	for {
+		current := c.sessionLifetime.Load()
+		if current != 0 && time.Duration(current) <= idle {
+			return
+		}
+
+		if c.sessionLifetime.CompareAndSwap(current, int64(idle)) {
+			return
+		}
+	}
+}
+
+// Authentications returns the number of successful authentications.
+func (c *Client) Authentications() uint64 {
+	return c.authentications.Load()
+}
+
+// createSession logs in and saves the new session.
+func (c *Client) createSession(ctx context.Context) error {
+	username, password, err := c.credentials(ctx)
 	if err != nil {
-		return true, err
+		return fmt.Errorf("failed to get credentials: %w", err)
 	}
 
-	login := response.Login{}
+	apiVersion := c.APIVersion()
+	if apiVersion == APIVersionAuto {
+		apiVersion = APIVersion4
+	}
+
+	login, res, err := c.login(ctx, apiVersion, username, password) //nolint:bodyclose // Already closed.
+
+	// Older firmwares do not support the current login endpoint, fall back
+	// to the legacy API.
+	var statusErr *StatusError
+	if c.APIVersion() == APIVersionAuto && errors.As(err, &statusErr) && !isLoginRefused(statusErr.StatusCode) {
+		apiVersion = APIVersion1
+		login, res, err = c.login(ctx, apiVersion, username, password) //nolint:bodyclose // Already closed.
+	}
 
-	res, err := c.doRequest(req, &login) //nolint:bodyclose // Already closed.
 	if err != nil {
-		if errors.Is(err, ErrStatusError) && res.StatusCode == http.StatusUnauthorized {
-			return true, ErrInvalidCredentials
-		}
+		return loginError(res, err)
+	}
 
-		return true, err
+	if login.Status != 0 {
+		return &LoginError{Reason: ErrInvalidCredentials, StatusCode: res.StatusCode}
 	}
 
+	c.apiVersion.Store(int32(apiVersion))
+
 	if login.Data.ContextID == "" {
-		return true, ErrEmptyContextID
+		return ErrEmptyContextID
 	}
 
 	// Find sessid cookie.
 	cookie, ok := findSessidCookie(res)
 	if !ok {
-		return true, ErrEmptySessidCookie
+		return ErrEmptySessidCookie
 	}
 
 	// Save session data and increment the current version of the session.
-	c.session.SetCredentials(login.Data.ContextID, cookie)
+	c.session.SetCredentials(login.Data.ContextID, cookie, login.Data.Username, login.Data.Groups)
+	c.authentications.Add(1)
+
+	if c.onSession != nil {
+		c.onSession()
+	}
 
-	return true, nil
+	return nil
+}
+
+// login sends a login request using the given API version.
+func (c *Client) login(ctx context.Context, apiVersion APIVersion, username, password string) (*response.Login, *http.Response, error) {
+	var (
+		req *http.Request
+		err error
+	)
+
+	if apiVersion == APIVersion1 {
+		req, err = c.newLegacyLoginRequest(ctx, username, password)
+	} else {
+		var payload []byte
+		if payload, err = json.Marshal(request.NewLogin(username, password)); err != nil {
+			return nil, nil, err
+		}
+
+		req, err = newRequest(ctx, ContentTypeWS, c.address, bytes.NewReader(payload), authorizationHeaderLogin)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	login := &response.Login{}
+
+	res, err := c.doRequest(req, login) //nolint:bodyclose // Already closed.
+
+	return login, res, err
 }
 
 // findSessidCookie searches the sessid Cookie in the login response.
@@ -269,37 +643,76 @@ func findSessidCookie(res *http.Response) (*http.Cookie, bool) {
 	return nil, false
 }
 
-// handleRequestError handles a JSON-encoded error contained in the body
-// of an API response. If there is no error, this function returns nil.
-func handleRequestError(body []byte) error {
-	// Exit early if body doesn't contain the "error" field.
-	if !strings.Contains(string(body), `"error"`) {
-		return nil
-	}
+// errorEnvelope contains the top-level fields of an API response that
+// describe an error. They are kept raw, and only decoded further when they are
+// set.
+type errorEnvelope struct {
+	ErrorCode   json.RawMessage `json:"error"`
+	Description string          `json:"description"`
+	Info        string          `json:"info"`
+	Errors      json.RawMessage `json:"errors"`
+	// Set by the legacy API.
+	Result json.RawMessage `json:"result"`
+}
 
-	// Unmarshal as an Error response (single error).
-	var respError response.Error
-	if err := json.Unmarshal(body, &respError); err != nil {
-		return err
+// err returns the error described by the envelope, all errors are returned if
+// there are several. Fields that do not have the type of an error field, such
+// as a non-numeric code, do not describe an error.
+func (e *errorEnvelope) err() error {
+	if isJSONObject(e.Result) {
+		if err := handleRequestError(e.Result); err != nil {
+			return err
+		}
 	}
 
-	if respError.ErrorCode != 0 || respError.Description != "" || respError.Info != "" {
-		return &respError
+	if isJSONSet(e.Errors) {
+		var errs []*response.Error
+		if err := json.Unmarshal(e.Errors, &errs); err == nil && len(errs) > 0 {
+			return &response.Errors{Errors: errs}
+		}
 	}
 
-	// Unmarshal as an Errors response (multiple errors).
-	var respErrors response.Errors
-	if err := json.Unmarshal(body, &respErrors); err != nil {
-		return err
-	}
+	if isJSONSet(e.ErrorCode) {
+		var code response.ErrorCode
+		if err := json.Unmarshal(e.ErrorCode, &code); err != nil {
+			return nil
+		}
 
-	if len(respErrors.Errors) > 0 {
-		return &respErrors
+		if code != 0 || e.Description != "" || e.Info != "" {
+			return &response.Error{
+				ErrorCode:   code,
+				Description: e.Description,
+				Info:        e.Info,
+			}
+		}
 	}
 
 	return nil
 }
 
+// isJSONSet returns true if a raw JSON field is present and not null.
+func isJSONSet(raw json.RawMessage) bool {
+	return len(raw) > 0 && !bytes.Equal(raw, []byte("null"))
+}
+
+// isJSONObject returns true if a raw JSON field is an object.
+func isJSONObject(raw json.RawMessage) bool {
+	return len(raw) > 0 && raw[0] == '{'
+}
+
+// handleRequestError handles a JSON-encoded error contained in the body
+// of an API response. If there is no error, this function returns nil.
+func handleRequestError(body []byte) error {
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		// Not an object, it cannot be an error. Invalid responses are
+		// reported when they are decoded.
+		return nil
+	}
+
+	return envelope.err()
+}
+
 // newRequest creates a new HTTP Post request with the Content-Type that
 // Livebox's API expects. An Authorization Header must be set, the
 // authorization parameter cannot be empty.
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// device is the result of a request that returns a device.
+type device struct {
+	Status struct {
+		Name        string `json:"Name"`
+		Description string `json:"Description"`
+	} `json:"status"`
+}
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		out    func() any
+		legacy bool
+		// Number of API errors expected, 0 if the response is successful.
+		errs int
+		// Whether a decoding error is expected.
+		decodeErr bool
+	}{
+		{
+			name: "success",
+			body: `{"status":true}`,
+			out:  func() any { return new(response.Status[bool]) },
+		},
+		{
+			name: "device named like error fields",
+			body: `{"status":{"Name":"errors","Description":"error description info"}}`,
+			out:  func() any { return new(device) },
+		},
+		{
+			name: "raw result with a device named like error fields",
+			body: `{"status":[{"Name":"error","Key":"errors"}]}`,
+			out:  func() any { return new(json.RawMessage) },
+		},
+		{
+			name: "non-numeric error field",
+			body: `{"status":true,"error":"none"}`,
+			out:  func() any { return new(any) },
+		},
+		{
+			name: "single error",
+			body: `{"status":null,"errors":[{"error":196618,"description":"Permission denied","info":"NMC"}]}`,
+			out:  func() any { return new(response.Status[*bool]) },
+			errs: 1,
+		},
+		{
+			name: "several errors",
+			body: `{"status":null,"errors":[{"error":13,"description":"Permission denied","info":""},{"error":196639,"description":"Function execution failed","info":"set"}]}`,
+			out:  func() any { return new(any) },
+			errs: 2,
+		},
+		{
+			name: "top-level error",
+			body: `{"error":13,"description":"Permission denied","info":""}`,
+			out:  func() any { return new(json.RawMessage) },
+			errs: 1,
+		},
+		{
+			name:   "legacy error",
+			body:   `{"result":{"status":null,"errors":[{"error":13,"description":"Permission denied","info":""}]}}`,
+			out:    func() any { return new(response.Status[*bool]) },
+			legacy: true,
+			errs:   1,
+		},
+		{
+			name: "error that does not match the result",
+			body: `{"status":"invalid","errors":[{"error":13,"description":"Permission denied","info":""}]}`,
+			out:  func() any { return new(response.Status[bool]) },
+			errs: 1,
+		},
+		{
+			name:      "invalid result",
+			body:      `{"status":"invalid"}`,
+			out:       func() any { return new(response.Status[bool]) },
+			decodeErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decodeResponse([]byte(tt.body), tt.out(), tt.legacy)
+
+			var apiErr *response.Error
+			var apiErrs *response.Errors
+
+			switch {
+			case tt.errs == 1 && errors.As(err, &apiErr):
+			case tt.errs > 0 && errors.As(err, &apiErrs) && len(apiErrs.Errors) == tt.errs:
+			case tt.errs == 0 && tt.decodeErr && err != nil && !errors.As(err, &apiErr) && !errors.As(err, &apiErrs):
+			case tt.errs == 0 && !tt.decodeErr && err == nil:
+			default:
+				t.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// Package client is the transport layer of the Livebox API client, it is the
+// only place where HTTP requests are created and sent. It handles:
+//
+//   - the encoding of requests for the current and the legacy API,
+//   - authentication, the session and its renewal,
+//   - the decoding of responses and of the errors they contain.
+//
+// The livebox package builds on top of it and must not send HTTP requests by
+// itself. Features that apply to every request, such as retries, rate
+// limiting, caching or metrics, are added in the livebox package as
+// interceptors or in Client.send. Typed methods only build requests, with the
+// constants of the sah package, and decode responses into the types of the
+// response package.
+package client
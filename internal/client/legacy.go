@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+)
+
+// APIVersion is the version of the Livebox API.
+type APIVersion int32
+
+const (
+	// APIVersionAuto detects the API version during the first login.
+	APIVersionAuto APIVersion = iota
+	// APIVersion4 is used by current firmwares (Livebox 4 and newer):
+	// requests are sent to the ws endpoint.
+	APIVersion4
+	// APIVersion1 is used by older firmwares (Livebox 3 and early Livebox 4
+	// firmwares): the login is sent to the authenticate endpoint and requests
+	// are sent to the sysbus endpoint.
+	APIVersion1
+)
+
+const (
+	// ContentTypeWS1 is used for request calls with the legacy API.
+	ContentTypeWS1 ContentType = "application/x-sah-ws-1-call+json"
+	// Login endpoint of the legacy API.
+	legacyAuthenticateEndpoint = "authenticate"
+	// Content type of the login requests of the legacy API.
+	contentTypeForm ContentType = "application/x-www-form-urlencoded"
+)
+
+// SetAPIVersion sets the API version used to communicate with the Livebox.
+func (c *Client) SetAPIVersion(v APIVersion) {
+	c.apiVersion.Store(int32(v))
+}
+
+// APIVersion returns the API version used to communicate with the Livebox,
+// APIVersionAuto if it is not detected yet.
+func (c *Client) APIVersion() APIVersion {
+	return APIVersion(c.apiVersion.Load())
+}
+
+// legacyResponse is the response of a call to the legacy API.
+type legacyResponse struct {
+	Result any `json:"result"`
+}
+
+// isLegacyCall returns true if req is a call to the legacy API, other than
+// the login.
+func isLegacyCall(req *http.Request) bool {
+	return ContentType(req.Header.Get("Content-Type")) == ContentTypeWS1 &&
+		req.Header.Get("Authorization") == ""
+}
+
+// newLegacyLoginRequest returns a login request for the legacy API. The
+// credentials are sent as a form in the body, so that they do not appear in
+// the URL, which is seen by proxies and written to access logs.
+func (c *Client) newLegacyLoginRequest(ctx context.Context, username, password string) (*http.Request, error) {
+	u := strings.TrimSuffix(c.sysbusAddress, sysbusEndpoint) + legacyAuthenticateEndpoint
+	body := url.Values{
+		"username": []string{username},
+		"password": []string{password},
+	}.Encode()
+
+	return newRequest(ctx, contentTypeForm, u, strings.NewReader(body), authorizationHeaderLogin)
+}
+
+// newLegacyRequest returns a request for the legacy API. The service and
+// method are part of the URL, only the parameters are sent in the body.
+func (c *Client) newLegacyRequest(ctx context.Context, req *request.Request, authorization string) (*http.Request, error) {
+	params := req.Parameters
+	if params == nil {
+		params = request.Parameters{}
+	}
+
+	payload, err := json.Marshal(map[string]any{"parameters": params})
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.sysbusAddress + "/" + strings.ReplaceAll(req.Service, ".", "/") + ":" + req.Method
+
+	return newRequest(ctx, ContentTypeWS1, u, bytes.NewReader(payload), authorization)
+}
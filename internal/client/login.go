@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+var (
+	// ErrTooManyLoginAttempts is returned when the Livebox refuses the login
+	// because of too many failed attempts. The account is locked for a while.
+	ErrTooManyLoginAttempts = errors.New("too many login attempts")
+	// ErrUnsupportedAuthentication is returned when the Livebox does not
+	// support the authentication scheme used by the client.
+	ErrUnsupportedAuthentication = errors.New("unsupported authentication scheme")
+)
+
+// LoginError is returned when the Livebox refuses the login. It matches its
+// Reason and the error returned by the API, if any.
+type LoginError struct {
+	// ErrInvalidCredentials, ErrTooManyLoginAttempts or
+	// ErrUnsupportedAuthentication.
+	Reason error
+	// HTTP status code of the login response.
+	StatusCode int
+	// Time to wait before trying again, 0 if unknown.
+	RetryAfter time.Duration
+	// Error returned by the API, nil if there is none.
+	Err error
+}
+
+func (e *LoginError) Error() string {
+	msg := fmt.Sprintf("login failed: %s", e.Reason)
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns the reason of the failure and the error returned by the API.
+func (e *LoginError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Reason}
+	}
+
+	return []error{e.Reason, e.Err}
+}
+
+// loginError returns the error describing why the login failed. err is
+// returned as is if the Livebox did not refuse the login.
+func loginError(res *http.Response, err error) error {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+
+	loginErr := &LoginError{StatusCode: statusErr.StatusCode, Err: statusErr.Err}
+
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		loginErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	switch statusErr.StatusCode {
+	case http.StatusTooManyRequests:
+		loginErr.Reason = ErrTooManyLoginAttempts
+	case http.StatusUnauthorized, http.StatusForbidden:
+		loginErr.Reason = ErrInvalidCredentials
+
+		// The Livebox delays the next attempts after several failures.
+		if loginErr.RetryAfter > 0 {
+			loginErr.Reason = ErrTooManyLoginAttempts
+		}
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		loginErr.Reason = ErrUnsupportedAuthentication
+	default:
+		if !response.IsPermissionDeniedError(statusErr.Err) {
+			return err
+		}
+
+		loginErr.Reason = ErrInvalidCredentials
+	}
+
+	return loginErr
+}
+
+// isLoginRefused returns true if the status code of a login response means
+// that the Livebox supports the login endpoint, but refused the login.
+func isLoginRefused(statusCode int) bool {
+	return statusCode == http.StatusUnauthorized ||
+		statusCode == http.StatusForbidden ||
+		statusCode == http.StatusTooManyRequests
+}
@@ -3,9 +3,53 @@ package client
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 )
 
+// SessionInfo describes an authenticated session.
+type SessionInfo struct {
+	// Username used to authenticate.
+	Username string
+	// Groups granted to the user.
+	Groups []string
+	// Time at which the session was created.
+	CreatedAt time.Time
+}
+
+// HasGroup returns true if the user is a member of the group.
+func (s *SessionInfo) HasGroup(group string) bool {
+	for _, g := range s.Groups {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SessionState is the state of a session, it can be saved to restore the
+// session later.
+type SessionState struct {
+	ContextID string
+	// Session cookie, in the "name=value" format.
+	Cookie    string
+	Username  string
+	Groups    []string
+	CreatedAt time.Time
+	// API version used by the session.
+	APIVersion APIVersion
+}
+
+// Age returns the time elapsed since the session was created.
+func (s *SessionInfo) Age() time.Duration {
+	return time.Since(s.CreatedAt)
+}
+
+// A session created less than this duration ago is known to be valid.
+const sessionFreshness = 5 * time.Second
+
 type session struct {
 	// mu guards the following fields.
 	mu sync.RWMutex
@@ -17,6 +61,8 @@ type session struct {
 	// Current version of the session. It is incremented each time the session
 	// is successfully renewed.
 	version uint64
+	// Information about the session.
+	info SessionInfo
 }
 
 // GetCredentials returns the current credentials and their version.
@@ -31,12 +77,85 @@ func (s *session) GetCredentials() (authorization, cookie string, version uint64
 	return fmt.Sprintf("X-Sah %s", s.contextID), fmt.Sprintf("%s=%s", s.sessid.Name, s.sessid.Value), s.version
 }
 
-// SetCredentials sets the current credentials and bumps the version.
-func (s *session) SetCredentials(contextID string, sessid *http.Cookie) {
+// SetCredentials sets the current credentials and bumps the version. The
+// groups are comma-separated.
+func (s *session) SetCredentials(contextID string, sessid *http.Cookie, username, groups string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.contextID = contextID
 	s.sessid = sessid
 	s.version++
+	s.info = SessionInfo{
+		Username:  username,
+		Groups:    splitGroups(groups),
+		CreatedAt: time.Now(),
+	}
+}
+
+// State returns the state of the current session. The second return value is
+// false if there is no session.
+func (s *session) State() (SessionState, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.version == 0 {
+		return SessionState{}, false
+	}
+
+	return SessionState{
+		ContextID: s.contextID,
+		Cookie:    s.sessid.Name + "=" + s.sessid.Value,
+		Username:  s.info.Username,
+		Groups:    s.info.Groups,
+		CreatedAt: s.info.CreatedAt,
+	}, true
+}
+
+// Restore sets the current credentials from a saved state and bumps the
+// version.
+func (s *session) Restore(state SessionState) {
+	name, value, _ := strings.Cut(state.Cookie, "=")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.contextID = state.ContextID
+	s.sessid = &http.Cookie{Name: name, Value: value}
+	s.version++
+	s.info = SessionInfo{
+		Username:  state.Username,
+		Groups:    state.Groups,
+		CreatedAt: state.CreatedAt,
+	}
+}
+
+// IsFresh returns true if version is the current version of the session, and
+// the session was created less than sessionFreshness ago.
+func (s *session) IsFresh(version uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.version == version && time.Since(s.info.CreatedAt) < sessionFreshness
+}
+
+// Info returns information about the current session. The second return value
+// is false if there is no session.
+func (s *session) Info() (SessionInfo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.info, s.version != 0
+}
+
+func splitGroups(groups string) []string {
+	var out []string
+
+	for _, group := range strings.Split(groups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			out = append(out, group)
+		}
+	}
+
+	return out
 }
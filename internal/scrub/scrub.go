@@ -0,0 +1,148 @@
+// Package scrub removes secrets and personal data from the requests and
+// responses of the Livebox API, before they are dumped, recorded or shared.
+package scrub
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Secrets scrubs the credentials and the session tokens: passwords, usernames,
+// context IDs and session cookies.
+var Secrets = New("SCRUBBED", "password", "username", "contextID")
+
+// Scrubber replaces the values of a set of fields, in JSON documents, query
+// strings, forms and HTTP dumps.
+type Scrubber struct {
+	// Value that replaces the scrubbed values.
+	Replacement string
+	// Called with the string values of the other fields of JSON documents,
+	// to scrub data that is not identified by its field, may be nil.
+	Strings func(s string) string
+
+	// Names of the fields, in lower case.
+	fields map[string]bool
+	// Matches the fields in HTTP dumps, the value follows the first group.
+	jsonRe  *regexp.Regexp
+	paramRe *regexp.Regexp
+}
+
+// Matches the secrets of HTTP dumps that are not fields: the context header
+// and the value of the session cookie.
+var (
+	contextHeaderRe = regexp.MustCompile(`(?i)((?:^|\n)X-Context:\s*)[^\r\n]*`)
+	sessidCookieRe  = regexp.MustCompile(`(/sessid=)[^;\r\n]*`)
+)
+
+// New returns a scrubber that replaces the values of the fields, compared
+// regardless of case, with replacement.
+func New(replacement string, fields ...string) *Scrubber {
+	s := &Scrubber{Replacement: replacement, fields: make(map[string]bool, len(fields))}
+
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		s.fields[strings.ToLower(field)] = true
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+
+	names := strings.Join(quoted, "|")
+	s.jsonRe = regexp.MustCompile(`(?i)("(?:` + names + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	s.paramRe = regexp.MustCompile(`(?i)((?:^|[?&\n])(?:` + names + `)=)[^&\s]*`)
+
+	return s
+}
+
+// Field returns true if the values of a field are scrubbed.
+func (s *Scrubber) Field(name string) bool {
+	return s.fields[strings.ToLower(name)]
+}
+
+// Value returns a copy of a JSON-decoded value whose string fields are
+// scrubbed.
+func (s *Scrubber) Value(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, value := range v {
+			if _, isString := value.(string); isString && s.Field(k) {
+				out[k] = s.Replacement
+				continue
+			}
+
+			out[k] = s.Value(value)
+		}
+
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = s.Value(value)
+		}
+
+		return out
+	case string:
+		if s.Strings != nil {
+			return s.Strings(v)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// JSON returns a scrubbed copy of a JSON document. Numbers are kept as is, as
+// counters may not fit in a float64. It returns false if the document is not
+// valid JSON.
+func (s *Scrubber) JSON(b []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false
+	}
+
+	out, err := json.Marshal(s.Value(v))
+	if err != nil {
+		return nil, false
+	}
+
+	return out, true
+}
+
+// Query returns a scrubbed copy of a query string or of a form. It is returned
+// as is if it cannot be parsed.
+func (s *Scrubber) Query(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+
+	for k := range values {
+		if s.Field(k) {
+			values[k] = []string{s.Replacement}
+		}
+	}
+
+	return values.Encode()
+}
+
+// Text returns a scrubbed copy of an HTTP dump, or of any text: the fields of
+// the JSON bodies, the parameters of the query strings and forms, the context
+// header and the session cookie are scrubbed. Values that are not identified
+// by a field are kept.
+func (s *Scrubber) Text(b []byte) []byte {
+	repl := []byte(`${1}"` + s.Replacement + `"`)
+	b = s.jsonRe.ReplaceAll(b, repl)
+
+	repl = []byte("${1}" + s.Replacement)
+	b = s.paramRe.ReplaceAll(b, repl)
+	b = contextHeaderRe.ReplaceAll(b, repl)
+	b = sessidCookieRe.ReplaceAll(b, repl)
+
+	return b
+}
@@ -0,0 +1,34 @@
+package livebox
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// Introspect returns the description of an object of the Livebox datamodel:
+// its parameters, its functions and their arguments. The path of the object
+// uses the same format as the service of a request (e.g. "NMC.OrangeTV").
+// Children are included up to the given depth, -1 includes all descendants.
+func (c *Client) Introspect(ctx context.Context, path string, depth int) (*response.Object, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	var out response.Object
+	if err := c.client.Get(
+		ctx,
+		strings.ReplaceAll(path, ".", "/"),
+		url.Values{"_restDepth": []string{strconv.Itoa(depth)}},
+		&out,
+	); err != nil {
+		c.log.ErrorContext(ctx, "Failed to introspect Livebox object", slog.String("path", path), slog.Any("error", err))
+		return nil, err
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,164 @@
+package livebox
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+	"github.com/Tomy2e/livebox-api-client/internal/client"
+)
+
+// DefaultKeepAliveInterval is the default interval between two keep-alive
+// requests.
+const DefaultKeepAliveInterval = 30 * time.Second
+
+// Minimum interval between two keep-alive requests when it is shortened
+// because sessions expired sooner, so that a single early expiration does not
+// make the client flood the Livebox.
+const minKeepAliveInterval = 10 * time.Second
+
+// DefaultKeepAliveProbe is the method called by default by keep-alive
+// requests.
+var DefaultKeepAliveProbe = sah.IoTService.GetStatus
+
+// WithKeepAliveInterval sets the maximum interval between two keep-alive
+// requests. The interval is shortened if sessions are observed to expire
+// sooner. If not used, DefaultKeepAliveInterval is used.
+func WithKeepAliveInterval(interval time.Duration) Opt {
+	return func(c *clientOpts) {
+		c.keepAliveInterval = interval
+	}
+}
+
+// WithKeepAliveProbe sets the method called by keep-alive requests, it must
+// not have side effects nor require parameters. It is also called to check
+// whether the session is still valid when a request is denied, it must be
+// allowed for the user. If not used, DefaultKeepAliveProbe is used.
+func WithKeepAliveProbe(method sah.Method) Opt {
+	return func(c *clientOpts) {
+		c.keepAliveProbe = method
+	}
+}
+
+// WithoutKeepAlive disables keep-alive requests, when the session is managed
+// by the caller, for instance with Client.RefreshSession.
+func WithoutKeepAlive() Opt {
+	return func(c *clientOpts) {
+		c.keepAliveInterval = -1
+	}
+}
+
+// keepAlive keeps the session alive while event listeners are running, as
+// event requests do not prevent the session from expiring. A request is sent
+// when no other request was sent during the keep-alive interval, it also
+// renews the session if it expired.
+type keepAlive struct {
+	// Maximum interval between two requests, keep-alive is disabled if it
+	// is negative.
+	interval time.Duration
+	probe    sah.Method
+
+	// mu guards the following fields.
+	mu sync.Mutex
+	// Number of running listeners.
+	users  int
+	cancel context.CancelFunc
+	// Closed when the keep-alive goroutine returns.
+	done chan struct{}
+}
+
+// startKeepAlive starts the keep-alive goroutine, if it is not running yet.
+func (c *Client) startKeepAlive() {
+	k := &c.keepAlive
+	if k.interval < 0 {
+		return
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.users++
+	if k.users == 1 {
+		c.log.Debug("Starting session keepalive goroutine")
+
+		var ctx context.Context
+		ctx, k.cancel = context.WithCancel(context.Background())
+		k.done = make(chan struct{})
+
+		go c.runKeepAlive(ctx, k.done)
+	}
+}
+
+// stopKeepAlive stops the keep-alive goroutine if there is no listener left,
+// it waits for the goroutine to return.
+func (c *Client) stopKeepAlive() {
+	k := &c.keepAlive
+	if k.interval < 0 {
+		return
+	}
+
+	k.mu.Lock()
+
+	k.users--
+	if k.users > 0 {
+		k.mu.Unlock()
+		return
+	}
+
+	k.cancel()
+	done := k.done
+	k.mu.Unlock()
+
+	<-done
+}
+
+// keepAliveInterval returns the interval between two keep-alive requests. It
+// is shortened if sessions were observed to expire sooner, down to
+// minKeepAliveInterval.
+func (c *Client) keepAliveInterval() time.Duration {
+	interval := c.keepAlive.interval
+
+	if lifetime := c.client.SessionLifetime(); lifetime > 0 {
+		interval = min(interval, max(lifetime/2, minKeepAliveInterval))
+	}
+
+	return interval
+}
+
+// runKeepAlive sends keep-alive requests until ctx is canceled.
+func (c *Client) runKeepAlive(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		interval := c.keepAliveInterval()
+
+		wait := interval - time.Since(c.client.LastActivity())
+		if wait <= 0 {
+			reqCtx, cancel := context.WithTimeout(ctx, interval)
+
+			out := json.RawMessage{}
+			if err := c.client.Request(
+				reqCtx,
+				client.ContentTypeWS,
+				c.keepAlive.probe.Request(nil),
+				&out,
+			); err != nil && ctx.Err() == nil {
+				c.log.Debug("Failed to send session keepalive request", slog.Any("error", err))
+			}
+
+			cancel()
+
+			wait = interval
+		}
+
+		select {
+		case <-ctx.Done():
+			c.log.Debug("Stopped session keepalive goroutine")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package livebox
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetLANConfig returns the IPv4 configuration of the LAN.
+func (c *Client) GetLANConfig(ctx context.Context) (*response.LANConfig, error) {
+	var out response.StatusData[bool, response.LANConfig]
+	if err := c.Request(ctx, sah.NMC.GetLANIP.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Data, nil
+}
+
+// SetLANConfig sets the IPv4 configuration of the LAN. The DHCP pool must be
+// part of the new subnet.
+//
+// If the address of the Livebox is changed, the current session breaks: the
+// response to this request may never be received and the client must be
+// recreated with the new address.
+func (c *Client) SetLANConfig(ctx context.Context, cfg *response.LANConfig) error {
+	current, err := c.GetLANConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current.Address != cfg.Address {
+		c.log.WarnContext(
+			ctx,
+			"Changing the Livebox LAN address, the session will break and the client must be recreated",
+			slog.String("from", current.Address),
+			slog.String("to", cfg.Address),
+		)
+	}
+
+	return c.requestBool(ctx, sah.NMC.SetLANIP.Request(request.Parameters{
+		"Address":        cfg.Address,
+		"Netmask":        cfg.Netmask,
+		"DHCPEnable":     cfg.DHCPEnable,
+		"DHCPMinAddress": cfg.DHCPMinAddress,
+		"DHCPMaxAddress": cfg.DHCPMaxAddress,
+	}))
+}
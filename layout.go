@@ -0,0 +1,72 @@
+package livebox
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// Interfaces from which the interface graph is traversed.
+var layoutRootInterfaces = []string{"data", "lan"}
+
+// nemoBaseMIB is the "base" MIB of a NeMo interface.
+type nemoBaseMIB struct {
+	Name   string              `json:"Name"`
+	Enable bool                `json:"Enable"`
+	Status bool                `json:"Status"`
+	Flags  string              `json:"Flags"`
+	LLIntf map[string]struct{} `json:"LLIntf"`
+	ULIntf map[string]struct{} `json:"ULIntf"`
+}
+
+// nemoVLANMIB is the "vlan" MIB of a NeMo interface.
+type nemoVLANMIB struct {
+	VLANID int `json:"VLANID"`
+}
+
+// GetInterfaceLayout returns the layout of the network interfaces of the
+// Livebox: ports, VLANs, bridges and the logical interfaces built on them.
+func (c *Client) GetInterfaceLayout(ctx context.Context) (response.InterfaceLayout, error) {
+	layout := response.InterfaceLayout{}
+
+	for _, root := range layoutRootInterfaces {
+		var out response.Status[struct {
+			Base map[string]nemoBaseMIB `json:"base"`
+			VLAN map[string]nemoVLANMIB `json:"vlan"`
+		}]
+		if err := c.Request(ctx, request.New("NeMo.Intf."+root, "getMIBs", request.Parameters{
+			"mibs":     "base vlan",
+			"traverse": "all",
+		}), &out); err != nil {
+			return nil, err
+		}
+
+		for name, base := range out.Status.Base {
+			layout[name] = &response.NetworkInterface{
+				Name:        name,
+				Enable:      base.Enable,
+				Status:      base.Status,
+				Flags:       base.Flags,
+				LowerLayers: sortedKeys(base.LLIntf),
+				UpperLayers: sortedKeys(base.ULIntf),
+				VLANID:      out.Status.VLAN[name].VLANID,
+			}
+		}
+	}
+
+	return layout, nil
+}
+
+// sortedKeys returns the sorted keys of m.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,184 @@
+package livebox
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// Listener receives the events requested by a call to Client.Events.
+type Listener struct {
+	// C receives the events. It is closed when the listener stops.
+	C <-chan *response.Event
+
+	client  *Client
+	events  []string
+	channel chan *response.Event
+	// Closed when the listener is closed.
+	done      chan struct{}
+	closeOnce sync.Once
+	overflow  OverflowPolicy
+
+	// Statistics.
+	received   atomic.Uint64
+	errors     atomic.Uint64
+	reconnects atomic.Uint64
+	dropped    atomic.Uint64
+
+	// errMu guards err.
+	errMu sync.Mutex
+	err   error
+
+	// mu guards the following fields.
+	mu     sync.Mutex
+	closed bool
+}
+
+// ListenerStats are the statistics of a listener.
+type ListenerStats struct {
+	// Number of events received, including dropped ones.
+	Events uint64
+	// Number of errors of the event stream.
+	Errors uint64
+	// Number of times the event stream was reconnected after an error.
+	Reconnects uint64
+	// Number of events dropped because the buffer was full, see
+	// WithEventOverflow.
+	Dropped uint64
+}
+
+// Close stops the listener and closes its channel. It is safe to call Close
+// several times.
+func (l *Listener) Close() {
+	l.closeOnce.Do(func() {
+		l.client.events.remove(l)
+		l.close()
+		l.client.stopKeepAlive()
+	})
+}
+
+// Err returns the error of the event stream while it is disconnected, or the
+// error of the context if the listener was stopped by its context. It returns
+// nil otherwise.
+func (l *Listener) Err() error {
+	l.errMu.Lock()
+	defer l.errMu.Unlock()
+
+	return l.err
+}
+
+// Stats returns the statistics of the listener.
+func (l *Listener) Stats() ListenerStats {
+	return ListenerStats{
+		Events:     l.received.Load(),
+		Errors:     l.errors.Load(),
+		Reconnects: l.reconnects.Load(),
+		Dropped:    l.dropped.Load(),
+	}
+}
+
+// setErr sets the error returned by Err.
+func (l *Listener) setErr(err error) {
+	l.errMu.Lock()
+	defer l.errMu.Unlock()
+
+	l.err = err
+}
+
+// reconnected records a reconnection of the event stream.
+func (l *Listener) reconnected() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	l.reconnects.Add(1)
+	l.setErr(nil)
+}
+
+// wants returns true if the listener requested the events of handler.
+func (l *Listener) wants(handler string) bool {
+	if len(l.events) == 0 {
+		return true
+	}
+
+	for _, event := range l.events {
+		if matchHandler(event, handler) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// send sends an event to the listener. If the buffer of the listener is full,
+// it blocks until the event is received or the listener is closed, unless the
+// overflow policy drops an event.
+func (l *Listener) send(ev *response.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	if ev.Event != nil {
+		l.received.Add(1)
+	}
+
+	if ev.Error != nil {
+		l.errors.Add(1)
+		l.setErr(ev.Error)
+	}
+
+	switch l.overflow {
+	case OverflowDropNewest:
+		select {
+		case l.channel <- ev:
+		default:
+			l.drop()
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case l.channel <- ev:
+				return
+			default:
+			}
+
+			select {
+			case <-l.channel:
+				l.drop()
+			default:
+				// The channel is not buffered.
+				l.drop()
+				return
+			}
+		}
+	default:
+		select {
+		case l.channel <- ev:
+		case <-l.done:
+		}
+	}
+}
+
+// drop records a dropped event.
+func (l *Listener) drop() {
+	l.dropped.Add(1)
+	l.client.metrics.observeEventDropped()
+}
+
+// close closes the channel of the listener.
+func (l *Listener) close() {
+	close(l.done)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closed = true
+	close(l.channel)
+}
@@ -0,0 +1,259 @@
+package livebox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/internal/client"
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the buckets of the
+// request latency histogram.
+var LatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Stats is a snapshot of the statistics of a client.
+type Stats struct {
+	// Number of requests sent, by "service:method". The dynamic part of
+	// services such as "Devices.Device.<key>" is replaced with "*", so that
+	// the number of keys is bounded. Retries are not counted.
+	Requests map[string]uint64
+	// Number of errors, by error code. Livebox API errors use their numeric
+	// code, other errors use "http_<status>", "network", "canceled",
+	// "timeout" or "other".
+	Errors map[string]uint64
+	// Number of successful logins, including the first one.
+	Authentications uint64
+	// Number of times event listeners reconnected after an error.
+	EventReconnects uint64
+	// Number of events dropped because the buffer of a listener was full,
+	// see WithEventOverflow.
+	EventsDropped uint64
+	// Number of events received from the Livebox.
+	EventsReceived uint64
+	// Number of events that could not be decoded, they are passed to the
+	// listeners as errors.
+	EventDecodeErrors uint64
+	// Time between the reception of events and their delivery to the
+	// listeners. A high latency means that listeners do not keep up.
+	EventDeliveryLatency LatencyHistogram
+	// Latency of requests, including retries.
+	Latency LatencyHistogram
+}
+
+// LatencyHistogram is a histogram of latencies.
+type LatencyHistogram struct {
+	// Upper bounds of the buckets, in seconds.
+	Buckets []float64
+	// Cumulative number of observations whose latency is lower than or
+	// equal to the upper bound of each bucket.
+	Counts []uint64
+	// Total number of observations.
+	Count uint64
+	// Sum of the latencies.
+	Sum time.Duration
+}
+
+func newLatencyHistogram() LatencyHistogram {
+	return LatencyHistogram{
+		Buckets: LatencyBuckets,
+		Counts:  make([]uint64, len(LatencyBuckets)),
+	}
+}
+
+// observe records a latency.
+func (h *LatencyHistogram) observe(latency time.Duration) {
+	h.Count++
+	h.Sum += latency
+
+	for i, bound := range h.Buckets {
+		if latency.Seconds() <= bound {
+			h.Counts[i]++
+		}
+	}
+}
+
+// clone returns a copy of the histogram.
+func (h *LatencyHistogram) clone() LatencyHistogram {
+	return LatencyHistogram{
+		Buckets: append([]float64(nil), h.Buckets...),
+		Counts:  append([]uint64(nil), h.Counts...),
+		Count:   h.Count,
+		Sum:     h.Sum,
+	}
+}
+
+// Prefixes of the services whose name ends with a dynamic part, such as the
+// key of a device or the name of an interface.
+var dynamicServices = []string{"Devices.Device.", "NeMo.Intf."}
+
+// metricMethod returns the "service:method" key of the statistics of a
+// request, with the dynamic part of the service replaced with "*".
+func metricMethod(service, method string) string {
+	for _, prefix := range dynamicServices {
+		if strings.HasPrefix(service, prefix) {
+			service = prefix + "*"
+			break
+		}
+	}
+
+	return service + ":" + method
+}
+
+// Stats returns a snapshot of the statistics of the client.
+func (c *Client) Stats() Stats {
+	return c.metrics.snapshot(c.client.Authentications())
+}
+
+// metrics collects the statistics of a client.
+type metrics struct {
+	// mu guards the following fields.
+	mu              sync.Mutex
+	requests        map[string]uint64
+	errors          map[string]uint64
+	eventReconnects uint64
+	eventsDropped   uint64
+	eventsReceived  uint64
+	decodeErrors    uint64
+	latency         LatencyHistogram
+	eventLatency    LatencyHistogram
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests:     map[string]uint64{},
+		errors:       map[string]uint64{},
+		latency:      newLatencyHistogram(),
+		eventLatency: newLatencyHistogram(),
+	}
+}
+
+// observeRequest records a request to the given method.
+func (m *metrics) observeRequest(method string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[method]++
+
+	if err != nil {
+		m.observeErrorLocked(err)
+	}
+
+	m.latency.observe(latency)
+}
+
+// observeError records an error that occurred outside of a request.
+func (m *metrics) observeError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.observeErrorLocked(err)
+}
+
+func (m *metrics) observeErrorLocked(err error) {
+	for _, code := range errorCodes(err) {
+		m.errors[code]++
+	}
+}
+
+// observeEventReconnect records a reconnection of an event listener.
+func (m *metrics) observeEventReconnect() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.eventReconnects++
+}
+
+// observeEventDropped records an event dropped by a listener.
+func (m *metrics) observeEventDropped() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.eventsDropped++
+}
+
+// observeEventReceived records an event received from the Livebox.
+func (m *metrics) observeEventReceived(decodeErr error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.eventsReceived++
+	if decodeErr != nil {
+		m.decodeErrors++
+	}
+}
+
+// observeEventDelivered records the delivery of an event to the listeners.
+func (m *metrics) observeEventDelivered(latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.eventLatency.observe(latency)
+}
+
+func (m *metrics) snapshot(authentications uint64) Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := Stats{
+		Requests:             make(map[string]uint64, len(m.requests)),
+		Errors:               make(map[string]uint64, len(m.errors)),
+		Authentications:      authentications,
+		EventReconnects:      m.eventReconnects,
+		EventsDropped:        m.eventsDropped,
+		EventsReceived:       m.eventsReceived,
+		EventDecodeErrors:    m.decodeErrors,
+		EventDeliveryLatency: m.eventLatency.clone(),
+		Latency:              m.latency.clone(),
+	}
+
+	for k, v := range m.requests {
+		stats.Requests[k] = v
+	}
+
+	for k, v := range m.errors {
+		stats.Errors[k] = v
+	}
+
+	return stats
+}
+
+// errorCodes returns the codes used to count an error.
+func errorCodes(err error) []string {
+	var respErrors *response.Errors
+	if errors.As(err, &respErrors) {
+		codes := make([]string, 0, len(respErrors.Errors))
+		for _, e := range respErrors.Errors {
+			codes = append(codes, strconv.Itoa(int(e.ErrorCode)))
+		}
+
+		return codes
+	}
+
+	var respError *response.Error
+	if errors.As(err, &respError) {
+		return []string{strconv.Itoa(int(respError.ErrorCode))}
+	}
+
+	var statusErr *client.StatusError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &statusErr):
+		return []string{fmt.Sprintf("http_%d", statusErr.StatusCode)}
+	case errors.Is(err, context.Canceled):
+		return []string{"canceled"}
+	case errors.Is(err, context.DeadlineExceeded):
+		return []string{"timeout"}
+	case errors.As(err, &netErr):
+		return []string{"network"}
+	default:
+		return []string{"other"}
+	}
+}
@@ -0,0 +1,117 @@
+// Package metrics exposes the statistics of a Livebox client, and the state
+// of the Livebox, as Prometheus metrics:
+//
+//	prometheus.MustRegister(metrics.NewCollector(client))
+//	prometheus.MustRegister(metrics.NewLiveboxCollector(client))
+package metrics
+
+import (
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "livebox_client"
+
+var (
+	requestsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "requests_total"),
+		"Number of requests sent to the Livebox API, by method. Dynamic parts of services are replaced with *.",
+		[]string{"method"}, nil,
+	)
+	errorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "errors_total"),
+		"Number of errors returned by the Livebox API, by error code.",
+		[]string{"code"}, nil,
+	)
+	authenticationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "authentications_total"),
+		"Number of successful logins.",
+		nil, nil,
+	)
+	eventReconnectsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "event_reconnects_total"),
+		"Number of times event listeners reconnected after an error.",
+		nil, nil,
+	)
+	eventsDroppedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "events_dropped_total"),
+		"Number of events dropped because the buffer of a listener was full.",
+		nil, nil,
+	)
+	eventsReceivedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "events_received_total"),
+		"Number of events received from the Livebox.",
+		nil, nil,
+	)
+	eventDecodeErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "event_decode_errors_total"),
+		"Number of events that could not be decoded.",
+		nil, nil,
+	)
+	eventLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "event_delivery_duration_seconds"),
+		"Time between the reception of events and their delivery to the listeners.",
+		nil, nil,
+	)
+	latencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "request_duration_seconds"),
+		"Latency of requests sent to the Livebox API.",
+		nil, nil,
+	)
+)
+
+// Collector is a Prometheus collector for the statistics of a client.
+type Collector struct {
+	client *livebox.Client
+}
+
+// NewCollector returns a collector for the statistics of the given client.
+func NewCollector(client *livebox.Client) *Collector {
+	return &Collector{client: client}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- requestsDesc
+	ch <- errorsDesc
+	ch <- authenticationsDesc
+	ch <- eventReconnectsDesc
+	ch <- eventsDroppedDesc
+	ch <- eventsReceivedDesc
+	ch <- eventDecodeErrorsDesc
+	ch <- eventLatencyDesc
+	ch <- latencyDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.Stats()
+
+	for method, count := range stats.Requests {
+		ch <- prometheus.MustNewConstMetric(requestsDesc, prometheus.CounterValue, float64(count), method)
+	}
+
+	for code, count := range stats.Errors {
+		ch <- prometheus.MustNewConstMetric(errorsDesc, prometheus.CounterValue, float64(count), code)
+	}
+
+	ch <- prometheus.MustNewConstMetric(authenticationsDesc, prometheus.CounterValue, float64(stats.Authentications))
+	ch <- prometheus.MustNewConstMetric(eventReconnectsDesc, prometheus.CounterValue, float64(stats.EventReconnects))
+	ch <- prometheus.MustNewConstMetric(eventsDroppedDesc, prometheus.CounterValue, float64(stats.EventsDropped))
+
+	ch <- prometheus.MustNewConstMetric(eventsReceivedDesc, prometheus.CounterValue, float64(stats.EventsReceived))
+	ch <- prometheus.MustNewConstMetric(eventDecodeErrorsDesc, prometheus.CounterValue, float64(stats.EventDecodeErrors))
+
+	ch <- histogram(latencyDesc, &stats.Latency)
+	ch <- histogram(eventLatencyDesc, &stats.EventDeliveryLatency)
+}
+
+// histogram returns a Prometheus histogram from a latency histogram.
+func histogram(desc *prometheus.Desc, h *livebox.LatencyHistogram) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(h.Buckets))
+	for i, bound := range h.Buckets {
+		buckets[bound] = h.Counts[i]
+	}
+
+	return prometheus.MustNewConstHistogram(desc, h.Count, h.Sum.Seconds(), buckets)
+}
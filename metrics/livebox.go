@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const liveboxNamespace = "livebox"
+
+// Maximum duration of a scrape of the Livebox.
+const scrapeTimeout = 10 * time.Second
+
+var (
+	scrapeSuccessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "scrape", "collector_success"),
+		"Whether the collector succeeded to get its metrics from the Livebox.",
+		[]string{"collector"}, nil,
+	)
+	infoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "", "info"),
+		"Information about the Livebox, the value is always 1.",
+		[]string{"manufacturer", "model", "hardware_version", "software_version"}, nil,
+	)
+	uptimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "", "uptime_seconds"),
+		"Time since the last boot of the Livebox.",
+		nil, nil,
+	)
+	wanLinkUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "wan", "link_up"),
+		"Whether the physical link of the WAN connection is up.",
+		nil, nil,
+	)
+	wanInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "wan", "info"),
+		"Information about the WAN connection, the value is always 1.",
+		[]string{"link_type", "protocol", "connection_state"}, nil,
+	)
+	interfaceReceiveBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "interface", "receive_bytes_total"),
+		"Number of bytes received on the interface.",
+		[]string{"interface"}, nil,
+	)
+	interfaceTransmitBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "interface", "transmit_bytes_total"),
+		"Number of bytes transmitted on the interface.",
+		[]string{"interface"}, nil,
+	)
+	interfaceReceivePacketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "interface", "receive_packets_total"),
+		"Number of packets received on the interface.",
+		[]string{"interface"}, nil,
+	)
+	interfaceTransmitPacketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "interface", "transmit_packets_total"),
+		"Number of packets transmitted on the interface.",
+		[]string{"interface"}, nil,
+	)
+	interfaceReceiveErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "interface", "receive_errors_total"),
+		"Number of receive errors on the interface.",
+		[]string{"interface"}, nil,
+	)
+	interfaceTransmitErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "interface", "transmit_errors_total"),
+		"Number of transmit errors on the interface.",
+		[]string{"interface"}, nil,
+	)
+	devicesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(liveboxNamespace, "", "devices"),
+		"Number of devices known by the Livebox.",
+		[]string{"active"}, nil,
+	)
+)
+
+// LiveboxCollector is a Prometheus collector for the state of a Livebox: its
+// uptime, its WAN connection, the traffic of its interfaces and its devices.
+// The Livebox is queried on each scrape.
+type LiveboxCollector struct {
+	client *livebox.Client
+}
+
+// NewLiveboxCollector returns a collector for the state of the Livebox of the
+// given client.
+func NewLiveboxCollector(client *livebox.Client) *LiveboxCollector {
+	return &LiveboxCollector{client: client}
+}
+
+// Describe implements prometheus.Collector.
+func (c *LiveboxCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- scrapeSuccessDesc
+	ch <- infoDesc
+	ch <- uptimeDesc
+	ch <- wanLinkUpDesc
+	ch <- wanInfoDesc
+	ch <- interfaceReceiveBytesDesc
+	ch <- interfaceTransmitBytesDesc
+	ch <- interfaceReceivePacketsDesc
+	ch <- interfaceTransmitPacketsDesc
+	ch <- interfaceReceiveErrorsDesc
+	ch <- interfaceTransmitErrorsDesc
+	ch <- devicesDesc
+}
+
+// Collect implements prometheus.Collector. The metrics of a collector that
+// fails are not sent, the failure is reported by the
+// livebox_scrape_collector_success metric.
+func (c *LiveboxCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	for _, collector := range []struct {
+		name    string
+		collect func(ctx context.Context, ch chan<- prometheus.Metric) error
+	}{
+		{"device_info", c.collectDeviceInfo},
+		{"wan", c.collectWAN},
+		{"interfaces", c.collectInterfaces},
+		{"devices", c.collectDevices},
+	} {
+		success := 1.0
+		if err := collector.collect(ctx, ch); err != nil {
+			success = 0
+		}
+
+		ch <- prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, success, collector.name)
+	}
+}
+
+func (c *LiveboxCollector) collectDeviceInfo(ctx context.Context, ch chan<- prometheus.Metric) error {
+	info, err := c.client.GetDeviceInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1,
+		info.Manufacturer, info.ModelName, info.HardwareVersion, info.SoftwareVersion)
+	ch <- prometheus.MustNewConstMetric(uptimeDesc, prometheus.GaugeValue, float64(info.UpTime))
+
+	return nil
+}
+
+func (c *LiveboxCollector) collectWAN(ctx context.Context, ch chan<- prometheus.Metric) error {
+	status, err := c.client.GetWANStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	up := 0.0
+	if status.LinkState == "up" {
+		up = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(wanLinkUpDesc, prometheus.GaugeValue, up)
+	ch <- prometheus.MustNewConstMetric(wanInfoDesc, prometheus.GaugeValue, 1,
+		status.LinkType, status.Protocol, status.ConnectionState)
+
+	return nil
+}
+
+func (c *LiveboxCollector) collectInterfaces(ctx context.Context, ch chan<- prometheus.Metric) error {
+	counters, err := c.client.GetInterfaceCounters(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, counter := range counters {
+		for desc, value := range map[*prometheus.Desc]uint64{
+			interfaceReceiveBytesDesc:    counter.RxBytes,
+			interfaceTransmitBytesDesc:   counter.TxBytes,
+			interfaceReceivePacketsDesc:  counter.RxPackets,
+			interfaceTransmitPacketsDesc: counter.TxPackets,
+			interfaceReceiveErrorsDesc:   counter.RxErrors,
+			interfaceTransmitErrorsDesc:  counter.TxErrors,
+		} {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value), counter.Interface)
+		}
+	}
+
+	return nil
+}
+
+func (c *LiveboxCollector) collectDevices(ctx context.Context, ch chan<- prometheus.Metric) error {
+	devices, err := c.client.Devices().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var active, inactive float64
+	for _, d := range devices {
+		if d.Active {
+			active++
+		} else {
+			inactive++
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(devicesDesc, prometheus.GaugeValue, active, "true")
+	ch <- prometheus.MustNewConstMetric(devicesDesc, prometheus.GaugeValue, inactive, "false")
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package livebox
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// Origin of the port forwarding rules managed by the user, the Livebox
+// prefixes their ID with it.
+const portForwardingOrigin = "webui"
+
+// GetPortForwardings returns the port forwarding rules created by the user,
+// sorted by ID.
+func (c *Client) GetPortForwardings(ctx context.Context) ([]response.PortForwardingRule, error) {
+	var out response.Status[map[string]response.PortForwardingRule]
+	if err := c.Request(ctx, sah.Firewall.GetPortForwarding.Request(request.Parameters{
+		"origin": portForwardingOrigin,
+	}), &out); err != nil {
+		return nil, err
+	}
+
+	rules := make([]response.PortForwardingRule, 0, len(out.Status))
+	for _, rule := range out.Status {
+		rules = append(rules, rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	return rules, nil
+}
+
+// SetPortForwarding creates or updates a port forwarding rule, and returns its
+// ID. The rule is identified by its ID, or by its description if the ID is
+// empty. The protocol defaults to ProtocolTCP.
+func (c *Client) SetPortForwarding(ctx context.Context, rule *response.PortForwardingRule) (string, error) {
+	id := strings.TrimPrefix(rule.ID, portForwardingOrigin+"_")
+	if id == "" {
+		id = rule.Description
+	}
+
+	protocol := rule.Protocol
+	if protocol == "" {
+		protocol = response.ProtocolTCP
+	}
+
+	var out response.Status[string]
+	if err := c.Request(ctx, sah.Firewall.SetPortForwarding.Request(request.Parameters{
+		"id":                    id,
+		"description":           rule.Description,
+		"origin":                portForwardingOrigin,
+		"sourceInterface":       "data",
+		"enable":                rule.Enable,
+		"persistent":            true,
+		"protocol":              protocol,
+		"externalPort":          rule.ExternalPort,
+		"internalPort":          rule.InternalPort,
+		"destinationIPAddress":  rule.DestinationIPAddress,
+		"destinationMACAddress": "",
+		"sourcePrefix":          rule.SourcePrefix,
+	}), &out); err != nil {
+		return "", err
+	}
+
+	if out.Status == "" {
+		return "", ErrUnsuccessful
+	}
+
+	return out.Status, nil
+}
+
+// DeletePortForwarding deletes the port forwarding rule with the given ID.
+func (c *Client) DeletePortForwarding(ctx context.Context, id string) error {
+	return c.requestBool(ctx, sah.Firewall.DeletePortForwarding.Request(request.Parameters{
+		"id":     id,
+		"origin": portForwardingOrigin,
+	}))
+}
@@ -0,0 +1,213 @@
+package livebox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// KnownDevicesStore persists the MAC addresses of the devices already seen on
+// the network, see Client.NotifyNewDevices.
+type KnownDevicesStore interface {
+	// Load returns the known MAC addresses, none if they were not saved yet.
+	Load(ctx context.Context) ([]string, error)
+	// Save saves the known MAC addresses.
+	Save(ctx context.Context, macs []string) error
+}
+
+// FileKnownDevices returns a KnownDevicesStore that saves the MAC addresses in
+// a JSON file. The file is replaced atomically, it is created if it does not
+// exist.
+func FileKnownDevices(path string) KnownDevicesStore {
+	return fileKnownDevices(path)
+}
+
+type fileKnownDevices string
+
+func (path fileKnownDevices) Load(context.Context) ([]string, error) {
+	b, err := os.ReadFile(string(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var macs []string
+	if err := json.Unmarshal(b, &macs); err != nil {
+		return nil, err
+	}
+
+	return macs, nil
+}
+
+func (path fileKnownDevices) Save(_ context.Context, macs []string) error {
+	b, err := json.MarshalIndent(macs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(string(path), b)
+}
+
+// Interval at which the notifier checks whether events may have been missed.
+const newDevicesCheckInterval = time.Minute
+
+// NotifyNewDevices calls notify the first time a device whose MAC address is
+// not in store appears on the network, until ctx is canceled or the returned
+// listener is closed. Devices that appeared while the notifier was not running
+// are notified when it starts. The devices are also checked again when events
+// may have been missed: after the event stream is reconnected, or when events
+// are dropped. If store is empty or does not exist, the devices currently
+// known by the Livebox are added to it without being notified.
+func (c *Client) NotifyNewDevices(ctx context.Context, store KnownDevicesStore, notify func(dev *response.Device)) (*Listener, error) {
+	macs, err := store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]struct{}, len(macs))
+	for _, mac := range macs {
+		if key, err := deviceKey(mac); err == nil {
+			known[key] = struct{}{}
+		}
+	}
+
+	// Watch events first, so that no device is missed.
+	l := c.Events(ctx, []string{Wildcard(EventDevices)})
+
+	// No device was saved yet, either because the store does not exist or
+	// because the Livebox listed none when it was created: the devices
+	// currently known are not new.
+	n := &newDeviceNotifier{client: c, store: store, known: known, notify: notify, seed: len(known) == 0}
+	if err := n.check(ctx); err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	go func() {
+		ticker := time.NewTicker(newDevicesCheckInterval)
+		defer ticker.Stop()
+
+		// Statistics of the listener at the last check, events may have
+		// been missed since then if the event stream was reconnected or
+		// if events were dropped.
+		last := l.Stats()
+
+		checkMissed := func() {
+			stats := l.Stats()
+			if stats.Reconnects == last.Reconnects && stats.Dropped == last.Dropped {
+				return
+			}
+
+			if err := n.check(ctx); err != nil {
+				c.log.WarnContext(ctx, "Failed to check new devices", slog.Any("error", err))
+				return
+			}
+
+			last = stats
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				checkMissed()
+			case ev, ok := <-l.C:
+				if !ok {
+					return
+				}
+
+				if ev.Status != nil && ev.Status.State == response.StreamReconnected {
+					checkMissed()
+					continue
+				}
+
+				if ev.Event == nil {
+					continue
+				}
+
+				key, err := deviceKey(strings.SplitN(strings.TrimPrefix(ev.Event.Handler, EventDevices+"."), ".", 2)[0])
+				if err != nil {
+					continue
+				}
+
+				if _, ok := n.known[key]; ok {
+					continue
+				}
+
+				if err := n.check(ctx); err != nil {
+					c.log.WarnContext(ctx, "Failed to check new devices", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+
+	return l, nil
+}
+
+// newDeviceNotifier notifies new devices.
+type newDeviceNotifier struct {
+	client *Client
+	store  KnownDevicesStore
+	known  map[string]struct{}
+	notify func(dev *response.Device)
+	// Do not notify the devices of the next check.
+	seed bool
+}
+
+// check saves the devices that are not known yet, and notifies them. They are
+// only added to the known devices once saved, a failed check is retried by
+// the next one.
+func (n *newDeviceNotifier) check(ctx context.Context) error {
+	devices, err := n.client.getDevices(ctx, "physical")
+	if err != nil {
+		return err
+	}
+
+	added := map[string]*response.Device{}
+
+	for i := range devices {
+		key, err := deviceKey(devices[i].PhysAddress)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := n.known[key]; !ok {
+			added[key] = &devices[i]
+		}
+	}
+
+	if len(added) == 0 && !n.seed {
+		return nil
+	}
+
+	macs := sortedKeys(n.known)
+	for key := range added {
+		macs = append(macs, key)
+	}
+	sort.Strings(macs)
+
+	if err := n.store.Save(ctx, macs); err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(added) {
+		n.known[key] = struct{}{}
+
+		if !n.seed {
+			n.notify(added[key])
+		}
+	}
+
+	n.seed = false
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package livebox
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the rate of requests sent by the client to rps requests
+// per second, with bursts of up to burst requests. The limit is shared by all
+// the goroutines that use the client. Requests are not limited if unset.
+func WithRateLimit(rps float64, burst int) Opt {
+	return func(c *clientOpts) {
+		c.rateLimiter = newRateLimiter(rps, burst)
+	}
+}
+
+// rateLimiter is a token bucket rate limiter.
+type rateLimiter struct {
+	// Number of tokens added to the bucket per second.
+	rps float64
+	// Capacity of the bucket.
+	burst float64
+
+	// mu guards the following fields.
+	mu sync.Mutex
+	// Number of tokens in the bucket. It is negative when tokens were
+	// reserved by waiting callers.
+	tokens float64
+	// Last time tokens were added to the bucket.
+	last time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rps:    rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request can be sent or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rps <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rps)
+	l.last = now
+	l.tokens--
+	wait := time.Duration(max(0, -l.tokens/l.rps) * float64(time.Second))
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Give the reserved token back.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,322 @@
+// Package recorder provides an HTTP transport that records the exchanges with
+// a Livebox to a fixture file, and replays them later without a Livebox. It
+// makes it possible to capture the behavior of a given firmware once and test
+// against it offline:
+//
+//	rec, err := recorder.New("testdata/wan.json", recorder.ModeAuto, nil)
+//	if err != nil {
+//		return err
+//	}
+//
+//	client, err := livebox.NewClient(password, livebox.WithHTTPClient(rec.Client()))
+//	// Send requests...
+//
+//	err = rec.Save()
+//
+// Secrets are scrubbed before being recorded: usernames, passwords and context
+// IDs, in JSON bodies, forms and query strings, and the value of the session
+// cookie.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Tomy2e/livebox-api-client/internal/scrub"
+)
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeAuto replays the fixture file if it exists, and records it
+	// otherwise.
+	ModeAuto Mode = iota
+	// ModeRecord sends requests to the Livebox and records the exchanges.
+	ModeRecord
+	// ModeReplay replays the exchanges of the fixture file, no request is
+	// sent to the Livebox.
+	ModeReplay
+)
+
+// ErrNoInteraction is returned in replay mode when no recorded exchange
+// matches a request.
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Interaction is a recorded exchange.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request.
+type RecordedRequest struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	ContentType string `json:"contentType,omitempty"`
+	// Body of the request if it is JSON.
+	Body json.RawMessage `json:"body,omitempty"`
+	// Body of the request if it is not JSON.
+	RawBody string `json:"rawBody,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	// Body of the response if it is JSON.
+	Body json.RawMessage `json:"body,omitempty"`
+	// Body of the response if it is not JSON.
+	RawBody string `json:"rawBody,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records or replays exchanges.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	// Number of times each interaction was replayed.
+	replayed []int
+}
+
+// New returns a new Recorder that uses the fixture file at path. In record
+// mode, requests are sent using the next transport, http.DefaultTransport is
+// used if nil.
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{path: path, mode: mode, next: next}
+
+	if r.mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, &r.interactions); err != nil {
+			return nil, fmt.Errorf("failed to decode fixture file: %w", err)
+		}
+
+		r.replayed = make([]int, len(r.interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the effective mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that uses the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, rec)
+	}
+
+	res, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	if ct := res.Header.Get("Content-Type"); ct != "" {
+		header.Set("Content-Type", ct)
+	}
+
+	for _, cookie := range res.Header.Values("Set-Cookie") {
+		header.Add("Set-Cookie", string(scrub.Secrets.Text([]byte(cookie))))
+	}
+
+	recorded := RecordedResponse{
+		StatusCode: res.StatusCode,
+		Header:     header,
+	}
+	recorded.Body, recorded.RawBody = scrubBody(body, res.Header.Get("Content-Type"))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request:  rec,
+		Response: recorded,
+	})
+	r.mu.Unlock()
+
+	// The real response is returned to the caller, only the recording is
+	// scrubbed.
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, nil
+}
+
+// Save writes the recorded exchanges to the fixture file. It does nothing in
+// replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, b, 0o644)
+}
+
+// replay returns the recorded response of the first interaction matching the
+// request that was replayed the least. This way, identical requests get the
+// recorded responses in order, and the last one is reused once all of them
+// were replayed.
+func (r *Recorder) replay(req *http.Request, rec RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+
+	for i, interaction := range r.interactions {
+		if !interaction.Request.matches(rec) {
+			continue
+		}
+
+		if match == -1 || r.replayed[i] < r.replayed[match] {
+			match = i
+		}
+	}
+
+	if match == -1 {
+		return nil, fmt.Errorf("%w: %s %s %s", ErrNoInteraction, rec.Method, rec.Path, rec.Body)
+	}
+
+	r.replayed[match]++
+	recorded := r.interactions[match].Response
+
+	body := []byte(recorded.Body)
+	if recorded.RawBody != "" {
+		body = []byte(recorded.RawBody)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// newRecordedRequest returns the scrubbed recording of a request. The body of
+// the request is restored so that it can still be sent.
+func newRecordedRequest(req *http.Request) (RecordedRequest, error) {
+	rec := RecordedRequest{
+		Method:      req.Method,
+		Path:        scrubURI(req.URL),
+		ContentType: req.Header.Get("Content-Type"),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return rec, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return rec, err
+	}
+
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	rec.Body, rec.RawBody = scrubBody(body, rec.ContentType)
+
+	return rec, nil
+}
+
+func (rr *RecordedRequest) matches(other RecordedRequest) bool {
+	return rr.Method == other.Method &&
+		rr.Path == other.Path &&
+		rr.ContentType == other.ContentType &&
+		rr.RawBody == other.RawBody &&
+		bytes.Equal(compactJSON(rr.Body), compactJSON(other.Body))
+}
+
+// scrubURI returns the path and the query of a URL, with the value of secret
+// parameters scrubbed.
+func scrubURI(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.RequestURI()
+	}
+
+	scrubbedURL := *u
+	scrubbedURL.RawQuery = scrub.Secrets.Query(u.RawQuery)
+
+	return scrubbedURL.RequestURI()
+}
+
+// scrubBody returns the scrubbed recording of a body: a JSON body is returned
+// in the first value, any other body verbatim in the second one, except forms
+// whose secret parameters are scrubbed.
+func scrubBody(body []byte, contentType string) (json.RawMessage, string) {
+	if len(body) == 0 {
+		return nil, ""
+	}
+
+	if b, ok := scrub.Secrets.JSON(body); ok {
+		return b, ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return nil, scrub.Secrets.Query(string(body))
+	}
+
+	return nil, string(body)
+}
+
+func compactJSON(b []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return b
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,212 @@
+package recorder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Tomy2e/livebox-api-client/recorder"
+)
+
+const (
+	testPassword  = "s3cr3t-password"
+	testContextID = "s3cr3t-context"
+)
+
+// exchange is a request sent during the test, and the body of the response
+// that is expected once replayed.
+type exchange struct {
+	method      string
+	path        string
+	contentType string
+	body        string
+	want        string
+}
+
+var exchanges = []exchange{
+	{
+		method:      http.MethodPost,
+		path:        "/ws",
+		contentType: "application/x-sah-ws-4-call+json",
+		body:        `{"service":"sah.Device.Information","method":"createContext","parameters":{"username":"admin","password":"` + testPassword + `"}}`,
+		want:        `{"status":0,"data":{"contextID":"SCRUBBED","username":"SCRUBBED"}}`,
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/ws",
+		contentType: "application/x-sah-ws-4-call+json",
+		body:        `{"service":"DeviceInfo","method":"get","parameters":{}}`,
+		want:        `{"status":{"Manufacturer":"Sagemcom","NumberOfReboots":18446744073709551615}}`,
+	},
+	{
+		method: http.MethodGet,
+		path:   "/authenticate?username=admin&password=" + testPassword,
+		want:   "<html><body>Not JSON</body></html>\n",
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/authenticate",
+		contentType: "application/x-www-form-urlencoded",
+		body:        url.Values{"username": {"admin"}, "password": {testPassword}}.Encode(),
+		want:        "plain text, not JSON",
+	},
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		switch {
+		case strings.Contains(string(body), "createContext"):
+			w.Header().Set("Content-Type", "application/x-sah-ws-4-call+json")
+			w.Header().Set("Set-Cookie", "0123abcd/sessid="+testContextID+"; path=/")
+			_, _ = io.WriteString(w, `{"status":0,"data":{"contextID":"`+testContextID+`","username":"admin"}}`)
+		case strings.Contains(string(body), "DeviceInfo"):
+			w.Header().Set("Content-Type", "application/x-sah-ws-4-call+json")
+			_, _ = io.WriteString(w, `{"status":{"Manufacturer":"Sagemcom","NumberOfReboots":18446744073709551615}}`)
+		case r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = io.WriteString(w, "<html><body>Not JSON</body></html>\n")
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, "plain text, not JSON")
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func send(t *testing.T, client *http.Client, baseURL string, ex exchange) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(ex.method, baseURL+ex.path, strings.NewReader(ex.body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ex.contentType != "" {
+		req.Header.Set("Content-Type", ex.contentType)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", ex.method, ex.path, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res, string(body)
+}
+
+// sameBody returns true if two bodies are equal, JSON bodies are compared
+// regardless of their formatting and of the order of their fields.
+func sameBody(got, want string) bool {
+	var gotValue, wantValue any
+
+	if decodeJSON(want, &wantValue) != nil {
+		return got == want
+	}
+
+	return decodeJSON(got, &gotValue) == nil && reflect.DeepEqual(gotValue, wantValue)
+}
+
+func decodeJSON(s string, v any) error {
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+
+	return dec.Decode(v)
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	rec, err := recorder.New(path, recorder.ModeAuto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Mode() != recorder.ModeRecord {
+		t.Fatalf("expected record mode without a fixture file, got %d", rec.Mode())
+	}
+
+	for _, ex := range exchanges {
+		send(t, rec.Client(), srv.URL, ex)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	fixture, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{testPassword, testContextID} {
+		if strings.Contains(string(fixture), secret) {
+			t.Errorf("fixture file contains secret %q:\n%s", secret, fixture)
+		}
+	}
+
+	// The server is closed: responses can only come from the fixture file.
+	srv.Close()
+
+	replay, err := recorder.New(path, recorder.ModeAuto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if replay.Mode() != recorder.ModeReplay {
+		t.Fatalf("expected replay mode with a fixture file, got %d", replay.Mode())
+	}
+
+	for _, ex := range exchanges {
+		res, body := send(t, replay.Client(), srv.URL, ex)
+
+		if !sameBody(body, ex.want) {
+			t.Errorf("%s %s: expected body %q, got %q", ex.method, ex.path, ex.want, body)
+		}
+
+		if res.Header.Get("Content-Type") == "" {
+			t.Errorf("%s %s: content type was not replayed", ex.method, ex.path)
+		}
+	}
+
+	res, _ := send(t, replay.Client(), srv.URL, exchanges[0])
+	if cookie := res.Header.Get("Set-Cookie"); cookie != "0123abcd/sessid=SCRUBBED; path=/" {
+		t.Errorf("unexpected replayed cookie %q", cookie)
+	}
+}
+
+func TestReplayNoInteraction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(path, []byte("[]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := recorder.New(path, recorder.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://192.168.1.1/", nil)
+	if _, err := replay.RoundTrip(req); err == nil {
+		t.Error("expected an error for a request that was not recorded")
+	}
+}
@@ -0,0 +1,64 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetRemoteAccess returns the state of the remote web administration.
+func (c *Client) GetRemoteAccess(ctx context.Context) (*response.RemoteAccess, error) {
+	var out response.Status[response.RemoteAccess]
+	if err := c.Request(ctx, sah.RemoteAccess.Get.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Status, nil
+}
+
+// EnableRemoteAccess enables the remote web administration over HTTPS on the
+// given port. If port is 0, the port currently configured is kept. The source
+// prefix and the timeout currently configured are kept.
+func (c *Client) EnableRemoteAccess(ctx context.Context, port int) error {
+	ra, err := c.GetRemoteAccess(ctx)
+	if err != nil {
+		return err
+	}
+
+	if port == 0 {
+		port = ra.Port
+	}
+
+	return c.requestBool(ctx, sah.RemoteAccess.Enable.Request(request.Parameters{
+		"port":         port,
+		"secure":       true,
+		"timeout":      ra.Timeout,
+		"sourcePrefix": ra.SourcePrefix,
+	}))
+}
+
+// DisableRemoteAccess disables the remote web administration.
+func (c *Client) DisableRemoteAccess(ctx context.Context) error {
+	return c.requestBool(ctx, sah.RemoteAccess.Disable.Request(nil))
+}
+
+// GetRemoteAssistance returns whether the Orange support is allowed to access
+// the Livebox remotely.
+func (c *Client) GetRemoteAssistance(ctx context.Context) (bool, error) {
+	var out response.Status[struct {
+		Enable bool `json:"Enable"`
+	}]
+	if err := c.Request(ctx, sah.OrangeRemoteAccess.Get.Request(nil), &out); err != nil {
+		return false, err
+	}
+
+	return out.Status.Enable, nil
+}
+
+// SetRemoteAssistance allows or denies the remote access of the Orange
+// support to the Livebox.
+func (c *Client) SetRemoteAssistance(ctx context.Context, enable bool) error {
+	return c.requestBool(ctx, sah.OrangeRemoteAccess.Set.Request(request.Parameters{"Enable": enable}))
+}
@@ -0,0 +1,49 @@
+package livebox_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+func TestEnableRemoteAccessKeepsRestrictions(t *testing.T) {
+	var enabled struct {
+		Port         int    `json:"port"`
+		Timeout      int    `json:"timeout"`
+		SourcePrefix string `json:"sourcePrefix"`
+	}
+
+	srv := httptest.NewServer(&apiHandler{respond: func(w http.ResponseWriter, call *apiCall) {
+		switch call.Method {
+		case "get":
+			_, _ = w.Write([]byte(`{"status":{"Enable":false,"Port":8443,"SecureMode":true,"Timeout":3600,"SourcePrefix":"203.0.113.0/24"}}`))
+		case "enable":
+			if err := json.Unmarshal(call.Parameters, &enabled); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"status":true}`))
+		default:
+			http.Error(w, "unexpected method", http.StatusNotFound)
+		}
+	}})
+	defer srv.Close()
+
+	c, err := livebox.NewClient("password", livebox.WithAddress(srv.URL), livebox.WithoutKeepAlive())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.EnableRemoteAccess(context.Background(), 9443); err != nil {
+		t.Fatal(err)
+	}
+
+	if enabled.Port != 9443 || enabled.SourcePrefix != "203.0.113.0/24" || enabled.Timeout != 3600 {
+		t.Errorf("expected the port to change and the restrictions to be kept, got %+v", enabled)
+	}
+}
@@ -2,22 +2,133 @@ package livebox
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
 	"github.com/Tomy2e/livebox-api-client/internal/client"
 )
 
+// ErrUnsuccessful is returned by typed methods when the Livebox reports that
+// the called function failed without giving further details.
+var ErrUnsuccessful = errors.New("livebox reported an unsuccessful call")
+
+// RequestError is returned by Client.Request when a request fails. It gives
+// the service and method that were called, and the ID of the request that is
+// also used in the logs of the client.
+type RequestError struct {
+	Service string
+	Method  string
+	ID      string
+	Err     error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request %s to %s:%s failed: %s", e.ID, e.Service, e.Method, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
 // Request sends a request to the Livebox API. If the client is not yet
 // authenticated, or the session is expired, the client will try to
 // authenticate using the admin password given during the creation
 // of the client.
-func (c *Client) Request(ctx context.Context, req *request.Request, out any) error {
-	err := c.client.Request(ctx, client.ContentTypeWS, req, out)
+//
+// Requests that fail with a transient error are retried if a retry policy
+// was set with request.WithRetryPolicy, or for read requests, with WithRetry.
+//
+// The behavior of the request can be tuned with options from the request
+// package, such as request.WithTimeout.
+//
+// Returned errors are wrapped in a *RequestError.
+func (c *Client) Request(ctx context.Context, req *request.Request, out any, opts ...request.Option) error {
+	id := strconv.FormatUint(c.requestID.Add(1), 10)
+
+	var err error
+	if c.closed.Load() {
+		err = ErrClientClosed
+	} else {
+		err = c.roundTrip(ctx, req, out, opts...)
+	}
+
 	if err != nil {
-		c.log.ErrorContext(ctx, "Failed to send request to Livebox", slog.Any("error", err))
+		err = &RequestError{Service: req.Service, Method: req.Method, ID: id, Err: err}
+		c.log.ErrorContext(ctx, "Failed to send request to Livebox", slog.String("request_id", id), slog.Any("error", err))
 	} else {
-		c.log.InfoContext(ctx, "Sent request to Livebox", slog.Any("request", req))
+		c.log.InfoContext(ctx, "Sent request to Livebox", slog.String("request_id", id), slog.Any("request", req))
 	}
 	return err
 }
+
+// send sends a request to the Livebox API, it applies the request timeout,
+// the rate limit and the retry policy.
+func (c *Client) send(ctx context.Context, req *request.Request, out any, opts ...request.Option) (err error) {
+	start := time.Now()
+	defer func() {
+		c.metrics.observeRequest(metricMethod(req.Service, req.Method), time.Since(start), err)
+	}()
+
+	o := request.NewOptions(opts)
+
+	timeout := o.Timeout
+	if _, ok := ctx.Deadline(); timeout == 0 && !ok {
+		timeout = c.requestTimeout
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	send := func() error {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		return c.client.Request(ctx, client.ContentTypeWS, req, out, opts...)
+	}
+
+	if o.RetryPolicy != nil {
+		return o.RetryPolicy.Retry(ctx, send)
+	}
+
+	if c.retry != nil && isReadMethod(req.Method) {
+		return c.retry.Retry(ctx, send)
+	}
+
+	return send()
+}
+
+// WithRequestTimeout sets the timeout of the requests whose context has no
+// deadline, and that do not use request.WithTimeout. The timeout includes the
+// time spent retrying the request. It prevents requests from hanging forever
+// when the Livebox stops responding, for instance during an upgrade. Event
+// requests are not affected.
+func WithRequestTimeout(timeout time.Duration) Opt {
+	return func(c *clientOpts) {
+		c.requestTimeout = timeout
+	}
+}
+
+// requestBool sends a request to a function that returns a boolean status.
+// ErrUnsuccessful is returned if the function returned false.
+func (c *Client) requestBool(ctx context.Context, req *request.Request) error {
+	var out response.Status[*bool]
+	if err := c.Request(ctx, req, &out); err != nil {
+		return err
+	}
+
+	if out.Status != nil && !*out.Status {
+		return ErrUnsuccessful
+	}
+
+	return nil
+}
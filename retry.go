@@ -0,0 +1,157 @@
+package livebox
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/internal/client"
+)
+
+// RetryPolicy configures how requests that failed with a transient error are
+// retried. Delays grow exponentially between attempts.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one. Requests are not
+	// retried if it is lower than 2.
+	MaxAttempts int
+	// Delay before the first retry.
+	InitialDelay time.Duration
+	// Maximum delay between two attempts.
+	MaxDelay time.Duration
+	// Factor applied to the delay after each retry. Defaults to 2.
+	Multiplier float64
+	// Fraction of the delay that is randomized, between 0 and 1.
+	Jitter float64
+	// Retryable returns true if the request that failed with err should be
+	// retried. IsTransientError is used if nil.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy is a sensible retry policy for the Livebox.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:  3,
+	InitialDelay: 500 * time.Millisecond,
+	MaxDelay:     5 * time.Second,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
+
+// IsTransientError returns true if err is likely to be transient: timeouts,
+// refused or reset connections, 5xx status codes, and "Function execution
+// failed" errors that the Livebox returns when it is busy. TLS and certificate
+// errors, such as ErrCertificateMismatch, are not transient.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if isTLSError(err) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var statusErr *client.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return response.IsRetryable(err)
+}
+
+// isTLSError returns true if err is a TLS handshake or certificate error.
+func isTLSError(err error) bool {
+	var (
+		recordErr    tls.RecordHeaderError
+		verifyErr    *tls.CertificateVerificationError
+		alertErr     tls.AlertError
+		invalidErr   x509.CertificateInvalidError
+		hostErr      x509.HostnameError
+		authorityErr x509.UnknownAuthorityError
+	)
+
+	return errors.Is(err, ErrCertificateMismatch) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &verifyErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &hostErr) ||
+		errors.As(err, &authorityErr)
+}
+
+// WithRetry retries read requests that fail with a transient error according
+// to the given policy. Read requests are requests to methods whose name starts
+// with "get" or "list". Other requests, such as Reboot or SetPortForwarding,
+// may already have been applied by the Livebox when they fail: they are only
+// retried if a policy is set for the request with request.WithRetryPolicy.
+// Requests are not retried if unset.
+func WithRetry(policy RetryPolicy) Opt {
+	return func(c *clientOpts) {
+		c.retry = &policy
+	}
+}
+
+// Retry calls f until it succeeds, it returns a non-transient error, the
+// maximum number of attempts is reached or ctx is done. It implements
+// request.RetryPolicy.
+func (p *RetryPolicy) Retry(ctx context.Context, f func() error) error {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsTransientError
+	}
+
+	delay := p.InitialDelay
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(p.jitter(delay)):
+		}
+
+		delay = p.next(delay)
+	}
+}
+
+// next returns the delay that follows the given delay.
+func (p *RetryPolicy) next(delay time.Duration) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay = time.Duration(float64(delay) * multiplier)
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay
+}
+
+// jitter randomizes the delay according to the jitter of the policy.
+func (p *RetryPolicy) jitter(delay time.Duration) time.Duration {
+	if p.Jitter <= 0 || delay <= 0 {
+		return delay
+	}
+
+	return delay + time.Duration(p.Jitter*float64(delay)*(2*rand.Float64()-1))
+}
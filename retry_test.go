@@ -0,0 +1,105 @@
+package livebox_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// timeoutError is a net.Error that timed out.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTransientError(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Post", URL: "http://192.168.1.1/ws", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"timeout", urlErr(timeoutError{}), true},
+		{"connection refused", urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), true},
+		{"connection reset", urlErr(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"certificate mismatch", urlErr(fmt.Errorf("tls: %w", livebox.ErrCertificateMismatch)), false},
+		{"unknown authority", urlErr(x509.UnknownAuthorityError{}), false},
+		{"dns failure", urlErr(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "livebox", IsNotFound: true}}), false},
+		{"other error", errors.New("invalid parameters"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := livebox.IsTransientError(tt.err); got != tt.want {
+				t.Errorf("IsTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryOnlyReads(t *testing.T) {
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(&apiHandler{respond: func(w http.ResponseWriter, _ *apiCall) {
+		calls.Add(1)
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}})
+	defer srv.Close()
+
+	policy := livebox.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}
+
+	c, err := livebox.NewClient("password",
+		livebox.WithAddress(srv.URL),
+		livebox.WithRetry(policy),
+		livebox.WithoutKeepAlive(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		send func() error
+		want int32
+	}{
+		{"read", func() error { _, err := c.GetWANStatus(ctx); return err }, 3},
+		{"write", func() error { return c.Reboot(ctx) }, 1},
+		{"write with a request policy", func() error {
+			return c.Request(ctx, sah.NMC.Reboot.Request(nil), new(any), request.WithRetryPolicy(&policy))
+		}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls.Store(0)
+
+			if err := tt.send(); err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if n := calls.Load(); n != tt.want {
+				t.Errorf("expected %d attempts, got %d", tt.want, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// routes registers the endpoints of the server.
+func (s *Server) routes() {
+	c := s.client
+	d := c.Devices()
+
+	get(s, "/info", c.GetDeviceInfo)
+	get(s, "/reboots", c.GetRebootHistory)
+	s.handle("POST /reboot", action(c.Reboot))
+
+	get(s, "/wan", c.GetWANStatus)
+	get(s, "/wan/mode", c.GetWANMode)
+	s.handle("POST /wan/reconnect", action(c.ReconnectWAN))
+
+	s.handle("GET /devices", s.listDevices)
+	s.handle("PATCH /devices/{mac}", s.updateDevice)
+	s.handle("DELETE /devices/{mac}", deviceAction(d.Delete))
+	s.handle("POST /devices/{mac}/wake", deviceAction(d.WakeOnLAN))
+	s.handle("POST /devices/{mac}/block", deviceAction(d.Block))
+	s.handle("POST /devices/{mac}/unblock", deviceAction(d.Unblock))
+
+	get(s, "/wifi", c.GetWiFiStatus)
+	s.handle("POST /wifi", toggle(c.SetWiFiEnabled))
+	get(s, "/wifi/access-points", c.GetWiFiAccessPoints)
+	get(s, "/wifi/guest", func(ctx context.Context) (enabled, error) {
+		enable, err := c.GetGuestWiFi(ctx)
+		return enabled{Enable: &enable}, err
+	})
+	s.handle("POST /wifi/guest", toggle(c.SetGuestWiFiEnabled))
+	s.handle("POST /wifi/wps", action(c.StartWPS))
+
+	get(s, "/dhcp/leases", c.GetDHCPLeases)
+	get(s, "/dhcp/static-leases", c.GetStaticLeases)
+	s.handle("POST /dhcp/static-leases", s.addStaticLease)
+	s.handle("DELETE /dhcp/static-leases/{mac}", deviceAction(c.DeleteStaticLease))
+
+	get(s, "/nat/rules", c.GetPortForwardings)
+	s.handle("POST /nat/rules", s.setPortForwarding)
+	s.handle("DELETE /nat/rules/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		return noContent(w, c.DeletePortForwarding(r.Context(), r.PathValue("id")))
+	})
+
+	get(s, "/calls", c.GetCallList)
+	get(s, "/topology", c.GetTopology)
+}
+
+// enabled is the body of the requests that enable or disable a feature.
+type enabled struct {
+	Enable *bool `json:"Enable"`
+}
+
+// get registers a GET endpoint that responds with the result of fn.
+func get[T any](s *Server, path string, fn func(ctx context.Context) (T, error)) {
+	s.handle("GET "+path, func(w http.ResponseWriter, r *http.Request) error {
+		v, err := fn(r.Context())
+		if err != nil {
+			return err
+		}
+
+		writeJSON(w, http.StatusOK, v)
+
+		return nil
+	})
+}
+
+// action returns a handler that calls fn.
+func action(fn func(ctx context.Context) error) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		return noContent(w, fn(r.Context()))
+	}
+}
+
+// deviceAction returns a handler that calls fn with the MAC address of the
+// path.
+func deviceAction(fn func(ctx context.Context, mac string) error) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		return noContent(w, fn(r.Context(), r.PathValue("mac")))
+	}
+}
+
+// toggle returns a handler that calls fn with the Enable field of the body.
+func toggle(fn func(ctx context.Context, enable bool) error) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		body, err := decode[enabled](r)
+		if err != nil {
+			return err
+		}
+
+		if body.Enable == nil {
+			return &badRequestError{errors.New("missing Enable field")}
+		}
+
+		return noContent(w, fn(r.Context(), *body.Enable))
+	}
+}
+
+// noContent responds without content, unless err is not nil.
+func noContent(w http.ResponseWriter, err error) error {
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// listDevices lists the devices, only the connected ones with ?active=true.
+func (s *Server) listDevices(w http.ResponseWriter, r *http.Request) error {
+	var activeOnly bool
+	if v := r.URL.Query().Get("active"); v != "" {
+		var err error
+		if activeOnly, err = strconv.ParseBool(v); err != nil {
+			return &badRequestError{errors.New("invalid active parameter, expected a boolean")}
+		}
+	}
+
+	devices, err := s.client.Devices().List(r.Context())
+	if err != nil {
+		return err
+	}
+
+	if activeOnly {
+		active := devices[:0]
+		for _, d := range devices {
+			if d.Active {
+				active = append(active, d)
+			}
+		}
+
+		devices = active
+	}
+
+	writeJSON(w, http.StatusOK, devices)
+
+	return nil
+}
+
+// updateDevice changes the name or the type of a device.
+func (s *Server) updateDevice(w http.ResponseWriter, r *http.Request) error {
+	body, err := decode[struct {
+		Name       *string `json:"Name"`
+		DeviceType *string `json:"DeviceType"`
+	}](r)
+	if err != nil {
+		return err
+	}
+
+	if body.Name == nil && body.DeviceType == nil {
+		return &badRequestError{errors.New("expected a Name or a DeviceType field")}
+	}
+
+	mac := r.PathValue("mac")
+
+	if body.Name != nil {
+		if err := s.client.Devices().SetName(r.Context(), mac, *body.Name); err != nil {
+			return err
+		}
+	}
+
+	if body.DeviceType != nil {
+		if err := s.client.Devices().SetType(r.Context(), mac, *body.DeviceType); err != nil {
+			return err
+		}
+	}
+
+	return noContent(w, nil)
+}
+
+// addStaticLease adds a static DHCP lease.
+func (s *Server) addStaticLease(w http.ResponseWriter, r *http.Request) error {
+	lease, err := decode[response.StaticLease](r)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.AddStaticLease(r.Context(), lease.MACAddress, lease.IPAddress); err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, lease)
+
+	return nil
+}
+
+// setPortForwarding creates or updates a port forwarding rule, and responds
+// with its ID.
+func (s *Server) setPortForwarding(w http.ResponseWriter, r *http.Request) error {
+	rule, err := decode[response.PortForwardingRule](r)
+	if err != nil {
+		return err
+	}
+
+	id, err := s.client.SetPortForwarding(r.Context(), &rule)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, struct {
+		ID string `json:"ID"`
+	}{id})
+
+	return nil
+}
@@ -0,0 +1,185 @@
+// Package server exposes the typed API of a Livebox client as a plain
+// REST/JSON interface, so that services that are not written in Go can use the
+// Livebox without implementing its protocol:
+//
+//	srv, err := server.New(client, token)
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = http.ListenAndServe("localhost:8080", srv)
+//
+// Requests are authenticated with a bearer token:
+//
+//	curl -H "Authorization: Bearer $TOKEN" http://localhost:8080/devices
+//
+// Responses are the JSON encoding of the types of the api/response package.
+// Errors are JSON objects with an Error field.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// ErrEmptyToken is returned by New when the token is empty.
+var ErrEmptyToken = errors.New("the token of the server must not be empty")
+
+// Maximum size of the body of a request.
+const maxBodySize = 1 << 20
+
+// Server is an http.Handler that serves the REST interface of a Livebox.
+type Server struct {
+	client *livebox.Client
+	token  []byte
+	mux    *http.ServeMux
+	log    *slog.Logger
+}
+
+// Opt is a Server option.
+type Opt func(s *Server)
+
+// WithLogger attaches a logger to the server, failed requests are logged.
+// Logging is disabled if unset.
+func WithLogger(log *slog.Logger) Opt {
+	return func(s *Server) {
+		s.log = log
+	}
+}
+
+// New returns a server for the Livebox of client. Requests must have an
+// "Authorization: Bearer <token>" header.
+func New(client *livebox.Client, token string, opts ...Opt) (*Server, error) {
+	if token == "" {
+		return nil, ErrEmptyToken
+	}
+
+	s := &Server{
+		client: client,
+		token:  []byte(token),
+		mux:    http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.log == nil {
+		s.log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	s.routes()
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="livebox"`)
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized returns true if the request has the token of the server.
+func (s *Server) authorized(r *http.Request) bool {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), s.token) == 1
+}
+
+// handle registers a handler whose errors are written as JSON. The handler
+// must not write a response when it returns an error.
+func (s *Server) handle(pattern string, handler func(w http.ResponseWriter, r *http.Request) error) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if err := handler(w, r); err != nil {
+			status := statusOf(err)
+			if status >= http.StatusInternalServerError {
+				s.log.WarnContext(r.Context(), "Request failed",
+					slog.String("pattern", pattern), slog.Any("error", err))
+			}
+
+			writeError(w, status, err)
+		}
+	})
+}
+
+// badRequestError is an error of the request of the client.
+type badRequestError struct {
+	err error
+}
+
+func (e *badRequestError) Error() string { return e.err.Error() }
+func (e *badRequestError) Unwrap() error { return e.err }
+
+// statusOf returns the HTTP status of an error.
+func statusOf(err error) int {
+	var (
+		badRequest *badRequestError
+		addrErr    *net.AddrError
+		urlErr     *url.Error
+		apiErr     *response.Error
+	)
+
+	switch {
+	case errors.As(err, &badRequest), errors.As(err, &addrErr):
+		return http.StatusBadRequest
+	case errors.Is(err, livebox.ErrInsufficientPermissions):
+		return http.StatusForbidden
+	case errors.As(err, &urlErr), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.As(err, &apiErr), errors.Is(err, livebox.ErrUnsuccessful):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// decode decodes the JSON body of a request.
+func decode[T any](r *http.Request) (T, error) {
+	var v T
+
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&v); err != nil {
+		return v, &badRequestError{fmt.Errorf("invalid body: %w", err)}
+	}
+
+	return v, nil
+}
+
+// writeJSON writes the JSON encoding of v. Errors can only be caused by the
+// connection of the client, they are ignored.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error as JSON.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"Error"`
+	}{err.Error()})
+}
@@ -0,0 +1,22 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/internal/client"
+)
+
+// SessionInfo describes the session of a client.
+type SessionInfo = client.SessionInfo
+
+// SessionInfo returns information about the current session of the client.
+// The second return value is false if the client is not authenticated yet.
+func (c *Client) SessionInfo() (SessionInfo, bool) {
+	return c.client.SessionInfo()
+}
+
+// RefreshSession authenticates again to renew the session of the client, it
+// can be used to refresh the session before it expires.
+func (c *Client) RefreshSession(ctx context.Context) error {
+	return c.client.Refresh(ctx)
+}
@@ -0,0 +1,109 @@
+package livebox_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+const permissionDenied = `{"errors":[{"error":13,"description":"Permission denied","info":""}]}`
+
+// memorySessionStore is a SessionStore that holds a session in memory.
+type memorySessionStore struct {
+	session *livebox.StoredSession
+}
+
+func (s *memorySessionStore) Load(context.Context) (*livebox.StoredSession, error) {
+	return s.session, nil
+}
+
+func (s *memorySessionStore) Save(_ context.Context, session *livebox.StoredSession) error {
+	s.session = session
+	return nil
+}
+
+// sessionHandler returns a handler that denies the requests sent with the
+// "old" context if it expired. DeviceInfo:get is always denied, other requests
+// are accepted.
+func sessionHandler(expired bool) *apiHandler {
+	return &apiHandler{respond: func(w http.ResponseWriter, call *apiCall) {
+		switch {
+		case expired && call.Context == "old":
+			_, _ = w.Write([]byte(permissionDenied))
+		case call.Service == "DeviceInfo" && call.Method == "get":
+			_, _ = w.Write([]byte(permissionDenied))
+		default:
+			_, _ = w.Write([]byte(`{"status":true}`))
+		}
+	}}
+}
+
+// newRestoredClient returns a client that restores a session created an hour
+// ago, with the "old" context.
+func newRestoredClient(t *testing.T, address string) *livebox.Client {
+	t.Helper()
+
+	store := &memorySessionStore{session: &livebox.StoredSession{
+		ContextID:  "old",
+		Cookie:     "0123abcd/sessid=old",
+		Username:   livebox.DefaultUsername,
+		Groups:     []string{"http", "admin"},
+		CreatedAt:  time.Now().Add(-time.Hour),
+		APIVersion: livebox.APIVersion4,
+	}}
+
+	c, err := livebox.NewClient("password",
+		livebox.WithAddress(address),
+		livebox.WithSessionStore(store),
+		livebox.WithoutKeepAlive(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestInsufficientPermissionsValidSession(t *testing.T) {
+	h := sessionHandler(false)
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	c := newRestoredClient(t, srv.URL)
+
+	if _, err := c.GetDeviceInfo(context.Background()); !errors.Is(err, livebox.ErrInsufficientPermissions) {
+		t.Fatalf("expected ErrInsufficientPermissions, got %v", err)
+	}
+
+	if n := h.logins.Load(); n != 0 {
+		t.Errorf("expected the valid session to be kept, got %d logins", n)
+	}
+}
+
+func TestInsufficientPermissionsExpiredSession(t *testing.T) {
+	h := sessionHandler(true)
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	c := newRestoredClient(t, srv.URL)
+
+	if _, err := c.GetWANStatus(context.Background()); err != nil {
+		t.Fatalf("expected the expired session to be renewed, got %v", err)
+	}
+
+	if n := h.logins.Load(); n != 1 {
+		t.Errorf("expected 1 login, got %d", n)
+	}
+
+	info, ok := c.SessionInfo()
+	if !ok || time.Since(info.CreatedAt) > time.Minute {
+		t.Errorf("expected a new session, got %+v", info)
+	}
+}
@@ -0,0 +1,183 @@
+package livebox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/internal/client"
+)
+
+// StoredSession is the state of a client saved by a SessionStore.
+type StoredSession struct {
+	ContextID string `json:"contextID"`
+	// Session cookie, in the "name=value" format.
+	Cookie     string     `json:"cookie"`
+	Username   string     `json:"username"`
+	Groups     []string   `json:"groups"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	APIVersion APIVersion `json:"apiVersion"`
+	// ID of the event channel and objects it watches, the channel is reused
+	// if it watches the objects of the listeners.
+	EventChannelID int      `json:"eventChannelID,omitempty"`
+	EventObjects   []string `json:"eventObjects,omitempty"`
+}
+
+// SessionStore persists the session of a client, so that it is reused after
+// a short restart of the process instead of logging in again. The event
+// channel is also reused, to receive the events the Livebox kept for it in
+// the meantime.
+type SessionStore interface {
+	// Load returns the saved session, or nil if there is none.
+	Load(ctx context.Context) (*StoredSession, error)
+	// Save saves the session.
+	Save(ctx context.Context, session *StoredSession) error
+}
+
+// FileSessionStore returns a SessionStore that saves the session in a JSON
+// file. The file contains secrets, it is only readable by its owner. It is
+// replaced atomically, so that it is never left truncated.
+func FileSessionStore(path string) SessionStore {
+	return fileSessionStore(path)
+}
+
+type fileSessionStore string
+
+func (path fileSessionStore) Load(context.Context) (*StoredSession, error) {
+	b, err := os.ReadFile(string(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var session StoredSession
+	if err := json.Unmarshal(b, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (path fileSessionStore) Save(_ context.Context, session *StoredSession) error {
+	b, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(string(path), b)
+}
+
+// writeFileAtomic writes a file through a temporary file that is renamed, so
+// that the file is never left truncated. The file is created with the 0600
+// mode, the mode of an existing file is replaced as well.
+func writeFileAtomic(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+// WithSessionStore restores the session saved in store when the client is
+// created, and saves the session each time it changes. A session saved for
+// another user than the one of the credentials is not restored.
+func WithSessionStore(store SessionStore) Opt {
+	return func(c *clientOpts) {
+		c.sessionStore = store
+	}
+}
+
+// restoreSession restores the session saved in the session store, unless it
+// belongs to another user than the one of the credentials.
+func (c *Client) restoreSession(ctx context.Context, credentials CredentialsProvider) {
+	stored, err := c.sessionStore.Load(ctx)
+	if err != nil {
+		c.log.WarnContext(ctx, "Failed to load saved session", slog.Any("error", err))
+		return
+	}
+
+	if stored == nil || stored.ContextID == "" {
+		return
+	}
+
+	username, _, err := credentials.Credentials(ctx)
+	if err != nil {
+		c.log.WarnContext(ctx, "Failed to get credentials to restore session", slog.Any("error", err))
+		return
+	}
+
+	if stored.Username != username {
+		c.log.InfoContext(ctx, "Saved session belongs to another user, it is not restored",
+			slog.String("username", stored.Username))
+		return
+	}
+
+	c.client.RestoreSession(client.SessionState{
+		ContextID:  stored.ContextID,
+		Cookie:     stored.Cookie,
+		Username:   stored.Username,
+		Groups:     stored.Groups,
+		CreatedAt:  stored.CreatedAt,
+		APIVersion: stored.APIVersion,
+	})
+	c.events.restore(stored.EventChannelID, stored.EventObjects)
+}
+
+// saveSession saves the session in the session store, if any.
+func (c *Client) saveSession(ctx context.Context) {
+	if c.sessionStore == nil {
+		return
+	}
+
+	state, ok := c.client.SessionState()
+	if !ok {
+		return
+	}
+
+	channelID, objects := c.events.channel()
+
+	if err := c.sessionStore.Save(ctx, &StoredSession{
+		ContextID:      state.ContextID,
+		Cookie:         state.Cookie,
+		Username:       state.Username,
+		Groups:         state.Groups,
+		CreatedAt:      state.CreatedAt,
+		APIVersion:     state.APIVersion,
+		EventChannelID: channelID,
+		EventObjects:   objects,
+	}); err != nil {
+		c.log.WarnContext(ctx, "Failed to save session", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,45 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetSambaConfig returns the configuration of the Samba file server.
+func (c *Client) GetSambaConfig(ctx context.Context) (*response.SambaConfig, error) {
+	var out response.Status[response.SambaConfig]
+	if err := c.Request(ctx, sah.Samba.Get.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Status, nil
+}
+
+// SetSambaConfig enables or disables the Samba file server and sets its
+// workgroup. The workgroup is left unchanged if empty.
+func (c *Client) SetSambaConfig(ctx context.Context, enable bool, workgroup string) error {
+	params := request.Parameters{"Enable": enable}
+	if workgroup != "" {
+		params["Workgroup"] = workgroup
+	}
+
+	return c.requestBool(ctx, sah.Samba.Set.Request(params))
+}
+
+// GetDLNAConfig returns the configuration of the DLNA media server.
+func (c *Client) GetDLNAConfig(ctx context.Context) (*response.DLNAConfig, error) {
+	var out response.Status[response.DLNAConfig]
+	if err := c.Request(ctx, sah.DLNA.Get.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Status, nil
+}
+
+// SetDLNAEnabled enables or disables the DLNA media server.
+func (c *Client) SetDLNAEnabled(ctx context.Context, enable bool) error {
+	return c.requestBool(ctx, sah.DLNA.Set.Request(request.Parameters{"Enable": enable}))
+}
@@ -0,0 +1,121 @@
+package livebox
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+)
+
+// WithSingleFlight coalesces identical read requests (same service, method and
+// parameters) that are sent concurrently into a single API call, the response
+// is shared between the callers. Read requests are requests to methods whose
+// name starts with "get" or "list", sent without request options.
+//
+// The shared call is not canceled when the context of the caller that started
+// it is canceled, so that the other callers still get the response. It is
+// bounded by the timeout set with WithRequestTimeout, or by
+// DefaultSingleFlightTimeout. Each caller stops waiting when its own context
+// is canceled.
+func WithSingleFlight() Opt {
+	return func(c *clientOpts) {
+		c.singleFlight = true
+	}
+}
+
+// DefaultSingleFlightTimeout is the timeout of the calls shared between
+// callers when WithRequestTimeout is not used.
+const DefaultSingleFlightTimeout = 30 * time.Second
+
+// isReadMethod returns true if the method only reads data.
+func isReadMethod(method string) bool {
+	return strings.HasPrefix(method, "get") || strings.HasPrefix(method, "list")
+}
+
+// flightCall is a call in progress or completed.
+type flightCall struct {
+	// Closed when the call completes.
+	done chan struct{}
+	res  json.RawMessage
+	err  error
+}
+
+// flightGroup coalesces identical calls.
+type flightGroup struct {
+	// Timeout of the shared calls.
+	timeout time.Duration
+
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// interceptor returns an interceptor that coalesces identical read requests.
+func (g *flightGroup) interceptor(next RoundTripFunc) RoundTripFunc {
+	return func(ctx context.Context, req *request.Request, out any, opts ...request.Option) error {
+		if len(opts) > 0 || !isReadMethod(req.Method) {
+			return next(ctx, req, out, opts...)
+		}
+
+		key, err := json.Marshal(req)
+		if err != nil {
+			return next(ctx, req, out, opts...)
+		}
+
+		res, err := g.do(ctx, string(key), func(ctx context.Context) (json.RawMessage, error) {
+			var res json.RawMessage
+			err := next(ctx, req, &res)
+
+			return res, err
+		})
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(res, out)
+	}
+}
+
+// do calls f, unless a call with the same key is in progress. In both cases,
+// it waits for the call to complete and returns its result, or returns early
+// if ctx is canceled. The call runs with a context that is detached from the
+// callers, it keeps their values but is only canceled by the timeout of the
+// group.
+func (g *flightGroup) do(ctx context.Context, key string, f func(ctx context.Context) (json.RawMessage, error)) (json.RawMessage, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+
+		go g.run(context.WithoutCancel(ctx), key, call, f)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-call.done:
+		return call.res, call.err
+	}
+}
+
+// run runs a call and removes it from the group once completed.
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, f func(ctx context.Context) (json.RawMessage, error)) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	call.res, call.err = f(ctx)
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	close(call.done)
+}
@@ -0,0 +1,90 @@
+package livebox
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+)
+
+// EventFilter returns true if an event must be passed to the handler of a
+// subscription.
+type EventFilter func(ev *response.EventData) bool
+
+// ReasonFilter returns a filter that accepts events with one of the given
+// reasons, such as "changed".
+func ReasonFilter(reasons ...string) EventFilter {
+	return func(ev *response.EventData) bool {
+		for _, reason := range reasons {
+			if ev.Object.Reason == reason {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Subscribe calls handler for each event whose handler matches pattern and
+// that is accepted by all the filters, until ctx is canceled or the returned
+// listener is closed. Errors of the event stream are also passed to handler.
+// Handler is called sequentially, from a single goroutine per subscription.
+//
+// The pattern is the path of an object, such as "Devices.Device", events of
+// the object and of its children are matched. The "*" wildcard matches any
+// part of the path, for instance "NeMo.Intf.*" matches the events of all the
+// interfaces.
+func (c *Client) Subscribe(ctx context.Context, pattern string, handler func(ev *response.Event), filters ...EventFilter) *Listener {
+	l := c.Events(ctx, []string{pattern})
+
+	go func() {
+		for ev := range l.C {
+			if ev.Event != nil && !acceptEvent(ev.Event, filters) {
+				continue
+			}
+
+			handler(ev)
+		}
+	}()
+
+	return l
+}
+
+// eventObject returns the object to watch to receive the events matched by
+// pattern: the part of the pattern before the first wildcard.
+func eventObject(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?["); i >= 0 {
+		pattern = pattern[:i]
+	}
+
+	return strings.TrimSuffix(pattern, ".")
+}
+
+// acceptEvent returns true if the event is accepted by all the filters.
+func acceptEvent(ev *response.EventData, filters []EventFilter) bool {
+	for _, filter := range filters {
+		if !filter(ev) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchHandler returns true if the handler of an event matches pattern, or is
+// a child of an object that matches pattern.
+func matchHandler(pattern, handler string) bool {
+	for {
+		if ok, _ := path.Match(pattern, handler); ok {
+			return true
+		}
+
+		i := strings.LastIndexByte(handler, '.')
+		if i < 0 {
+			return false
+		}
+
+		handler = handler[:i]
+	}
+}
@@ -0,0 +1,93 @@
+package livebox
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrCertificateMismatch is returned when the certificate presented by the
+// Livebox does not match the fingerprint set with WithCertificateFingerprint.
+var ErrCertificateMismatch = errors.New("livebox certificate does not match the expected fingerprint")
+
+// WithTLSConfig sets the TLS configuration used when the Livebox address uses
+// the https scheme. It is applied to the transport of the HTTP client, which
+// must be an *http.Transport if a custom HTTP client is used.
+func WithTLSConfig(config *tls.Config) Opt {
+	return func(c *clientOpts) {
+		c.tlsConfig = config.Clone()
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the certificate of the
+// Livebox. The Livebox uses a self-signed certificate that does not match its
+// address, so it cannot be verified by default. Prefer
+// WithCertificateFingerprint, which is not vulnerable to man-in-the-middle
+// attacks.
+func WithInsecureSkipVerify() Opt {
+	return func(c *clientOpts) {
+		c.tls().InsecureSkipVerify = true
+	}
+}
+
+// WithCertificateFingerprint only accepts the certificate of the Livebox if its
+// SHA-256 fingerprint matches the given one. The fingerprint is hex-encoded,
+// colons are ignored. This makes it possible to securely use the self-signed
+// certificate of the Livebox.
+func WithCertificateFingerprint(fingerprint string) Opt {
+	want := strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+
+	return func(c *clientOpts) {
+		config := c.tls()
+		// The chain is verified by VerifyPeerCertificate instead.
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return ErrCertificateMismatch
+			}
+
+			sum := sha256.Sum256(rawCerts[0])
+			if hex.EncodeToString(sum[:]) != want {
+				return ErrCertificateMismatch
+			}
+
+			return nil
+		}
+	}
+}
+
+// tls returns the TLS configuration of the options, it is created if needed.
+func (c *clientOpts) tls() *tls.Config {
+	if c.tlsConfig == nil {
+		c.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return c.tlsConfig
+}
+
+// withTLSConfig returns a copy of the HTTP client that uses the given TLS
+// configuration. The client is returned as is if its transport is not an
+// *http.Transport.
+func withTLSConfig(client *http.Client, config *tls.Config) *http.Client {
+	transport := http.DefaultTransport
+	if client.Transport != nil {
+		transport = client.Transport
+	}
+
+	t, ok := transport.(*http.Transport)
+	if !ok {
+		return client
+	}
+
+	t = t.Clone()
+	t.TLSClientConfig = config
+
+	c := *client
+	c.Transport = t
+
+	return &c
+}
@@ -0,0 +1,28 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetTopology returns the topology of the home network. The returned node is
+// the Livebox.
+func (c *Client) GetTopology(ctx context.Context) (*response.TopologyNode, error) {
+	var out response.Status[[]*response.TopologyNode]
+	if err := c.Request(
+		ctx,
+		sah.TopologyDiagnostics.BuildTopology.Request(request.Parameters{"SendXmlFile": false}),
+		&out,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(out.Status) == 0 {
+		return nil, ErrUnsuccessful
+	}
+
+	return out.Status[0], nil
+}
@@ -0,0 +1,73 @@
+package livebox
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// NewTransport returns a new HTTP transport tuned for the Livebox, it is used
+// when no custom HTTP client is set with WithHTTPClient. It can be customized
+// and used in a custom HTTP client:
+//
+//	t := livebox.NewTransport()
+//	t.Proxy = nil
+//	client, err := livebox.NewClient(password, livebox.WithHTTPClient(&http.Client{Transport: t}))
+//
+// Connections are kept alive to reuse the session with the single host, but
+// few of them are kept idle as the Livebox handles a limited number of
+// connections. Dials, TLS handshakes and responses whose headers are not
+// received within 30 seconds time out instead of hanging when the Livebox is
+// unresponsive, and compression is not negotiated as the Livebox does not
+// support it consistently.
+//
+// Event requests are long-polling requests, the response header timeout does
+// not apply to them: the client sends them with a copy of the transport
+// without it, and bounds them with their own deadline.
+func NewTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          2,
+		MaxIdleConnsPerHost:   2,
+		IdleConnTimeout:       30 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		DisableCompression:    true,
+	}
+}
+
+// newHTTPClient returns the HTTP client used when no custom HTTP client is
+// set. It has no global timeout, because event requests are long-polling
+// requests, see WithRequestTimeout instead.
+func newHTTPClient() *http.Client {
+	return &http.Client{Transport: NewTransport()}
+}
+
+// withoutResponseHeaderTimeout returns a copy of client whose transport has
+// no response header timeout, to send event requests. The client is returned
+// as is if its transport is not an *http.Transport or has no such timeout.
+func withoutResponseHeaderTimeout(client *http.Client) *http.Client {
+	if client == nil {
+		return nil
+	}
+
+	t, ok := client.Transport.(*http.Transport)
+	if !ok || t.ResponseHeaderTimeout == 0 {
+		return client
+	}
+
+	t = t.Clone()
+	t.ResponseHeaderTimeout = 0
+
+	c := *client
+	c.Transport = t
+
+	return &c
+}
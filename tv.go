@@ -0,0 +1,53 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// Devices having this tag are TV decoders.
+const tvDecoderExpression = "iptv"
+
+// GetTVStatus returns the status of the Orange TV service: subscription
+// state, multicast channels and known TV decoders.
+func (c *Client) GetTVStatus(ctx context.Context) (*response.TVStatus, error) {
+	var status response.StatusData[bool, struct {
+		IPTVStatus string `json:"IPTVStatus"`
+	}]
+	if err := c.Request(ctx, sah.NMCOrangeTV.GetIPTVStatus.Request(nil), &status); err != nil {
+		return nil, err
+	}
+
+	var multiScreens response.StatusData[bool, struct {
+		Enable bool `json:"Enable"`
+	}]
+	if err := c.Request(ctx, sah.NMCOrangeTV.GetIPTVMultiScreens.Request(nil), &multiScreens); err != nil {
+		return nil, err
+	}
+
+	var channels response.Status[[]response.IPTVChannel]
+	if err := c.Request(ctx, sah.NMCOrangeTV.GetIPTVConfig.Request(nil), &channels); err != nil {
+		return nil, err
+	}
+
+	decoders, err := c.GetTVDecoders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.TVStatus{
+		IPTVStatus:   status.Data.IPTVStatus,
+		MultiScreens: multiScreens.Data.Enable,
+		Channels:     channels.Status,
+		Decoders:     decoders,
+	}, nil
+}
+
+// GetTVDecoders returns the Orange TV decoders known by the Livebox. The
+// Active and IPAddress fields of the returned devices tell whether a decoder
+// is connected and which address it uses.
+func (c *Client) GetTVDecoders(ctx context.Context) ([]response.Device, error) {
+	return c.getDevices(ctx, tvDecoderExpression)
+}
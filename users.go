@@ -0,0 +1,18 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetUsers returns the local user accounts of the Livebox and their groups.
+func (c *Client) GetUsers(ctx context.Context) ([]response.User, error) {
+	var out response.Status[[]response.User]
+	if err := c.Request(ctx, sah.UserManagement.GetUsers.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return out.Status, nil
+}
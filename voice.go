@@ -0,0 +1,40 @@
+package livebox
+
+import (
+	"context"
+
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetCallList returns the call history of the Livebox phone lines.
+func (c *Client) GetCallList(ctx context.Context) ([]response.Call, error) {
+	var out response.Status[[]response.Call]
+	if err := c.Request(ctx, sah.VoiceServiceVoiceApplication.GetCallList.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return out.Status, nil
+}
+
+// ClearCallList deletes all the entries of the call history.
+func (c *Client) ClearCallList(ctx context.Context) error {
+	return c.requestBool(ctx, sah.VoiceServiceVoiceApplication.ClearCallList.Request(nil))
+}
+
+// GetVoiceTrunks returns the provisioned VoIP trunks and their lines. The SIP
+// URIs of the lines are masked, see response.MaskSIPURI.
+func (c *Client) GetVoiceTrunks(ctx context.Context) ([]response.VoiceTrunk, error) {
+	var out response.Status[[]response.VoiceTrunk]
+	if err := c.Request(ctx, sah.VoiceServiceVoiceApplication.ListTrunks.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	for _, trunk := range out.Status {
+		for i := range trunk.Lines {
+			trunk.Lines[i].URI = response.MaskSIPURI(trunk.Lines[i].URI)
+		}
+	}
+
+	return out.Status, nil
+}
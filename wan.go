@@ -0,0 +1,120 @@
+package livebox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// Name of the PPP interface of the WAN connection.
+const wanPPPInterface = "ppp_data"
+
+// GetPPPStatus returns the state of the PPP session of the WAN connection: the
+// ppp_data interface if it exists, otherwise the first enabled PPP interface,
+// in name order. ErrUnsuccessful is returned if the WAN connection does not use
+// PPP.
+func (c *Client) GetPPPStatus(ctx context.Context) (*response.PPPStatus, error) {
+	var out response.Status[struct {
+		PPP map[string]response.PPPStatus `json:"ppp"`
+	}]
+	if err := c.Request(ctx, sah.NeMoIntfData.GetMIBs.Request(request.Parameters{"mibs": "ppp"}), &out); err != nil {
+		return nil, err
+	}
+
+	if status, ok := out.Status.PPP[wanPPPInterface]; ok {
+		return &status, nil
+	}
+
+	names := make([]string, 0, len(out.Status.PPP))
+	for name := range out.Status.PPP {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if status := out.Status.PPP[name]; status.Enable {
+			return &status, nil
+		}
+	}
+
+	return nil, ErrUnsuccessful
+}
+
+// ErrWANLeftDisabled is returned by ReconnectWAN when the WAN connection could
+// not be enabled again. The internet access is down until it is enabled.
+var ErrWANLeftDisabled = errors.New("the WAN connection was left disabled")
+
+const (
+	// Timeout of each attempt to enable the WAN connection again.
+	wanEnableTimeout = 10 * time.Second
+	// Number of attempts to enable the WAN connection again.
+	wanEnableAttempts = 3
+)
+
+// ReconnectWAN forces the WAN connection to reconnect, which usually gives a
+// new public IP address. Requests sent to the Livebox keep working, but the
+// internet access is interrupted for a few seconds.
+//
+// Once disabled, the connection is enabled again even if ctx is canceled, and
+// the request is retried if it fails. ErrWANLeftDisabled is returned if it
+// still fails.
+func (c *Client) ReconnectWAN(ctx context.Context) error {
+	err := c.setPPPEnabled(ctx, false)
+
+	// The disable request may have been applied even if it failed.
+	policy := RetryPolicy{
+		MaxAttempts:  wanEnableAttempts,
+		InitialDelay: time.Second,
+		Retryable:    func(err error) bool { return !errors.Is(err, ErrClientClosed) },
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	if enableErr := policy.Retry(ctx, func() error {
+		ctx, cancel := context.WithTimeout(ctx, wanEnableTimeout)
+		defer cancel()
+
+		return c.setPPPEnabled(ctx, true)
+	}); enableErr != nil {
+		return fmt.Errorf("%w: %w", ErrWANLeftDisabled, enableErr)
+	}
+
+	return err
+}
+
+// setPPPEnabled enables or disables the PPP interface of the WAN connection.
+func (c *Client) setPPPEnabled(ctx context.Context, enable bool) error {
+	return c.requestBool(ctx, sah.NeMoIntfData.SetFirstParameter.Request(request.Parameters{
+		"name":  "Enable",
+		"value": enable,
+		"flag":  "ppp",
+	}))
+}
+
+// GetWANStatus returns the status of the WAN connection.
+func (c *Client) GetWANStatus(ctx context.Context) (*response.WANStatus, error) {
+	var out response.StatusData[bool, response.WANStatus]
+	if err := c.Request(ctx, sah.NMC.GetWANStatus.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Data, nil
+}
+
+// GetWANMode returns the mode of the WAN connection and its access technology
+// (FTTH, VDSL, ADSL, ...).
+func (c *Client) GetWANMode(ctx context.Context) (*response.WANMode, error) {
+	var out response.Status[struct {
+		WanMode string `json:"WanMode"`
+	}]
+	if err := c.Request(ctx, sah.NMC.Get.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return response.NewWANMode(out.Status.WanMode), nil
+}
@@ -0,0 +1,87 @@
+package livebox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tomy2e/livebox-api-client"
+)
+
+func TestReconnectWANCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var enables int
+
+	srv := httptest.NewServer(&apiHandler{respond: func(w http.ResponseWriter, call *apiCall) {
+		var params struct {
+			Value bool `json:"value"`
+		}
+		if err := json.Unmarshal(call.Parameters, &params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !params.Value {
+			// The caller gives up once the connection is disabled.
+			cancel()
+			_, _ = w.Write([]byte(`{"status":true}`))
+			return
+		}
+
+		// The first attempt to enable the connection fails.
+		if enables++; enables == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":true}`))
+	}})
+	defer srv.Close()
+
+	c, err := livebox.NewClient("password", livebox.WithAddress(srv.URL), livebox.WithoutKeepAlive())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The error of the canceled request is returned, but the connection is
+	// enabled again.
+	if err := c.ReconnectWAN(ctx); errors.Is(err, livebox.ErrWANLeftDisabled) {
+		t.Fatalf("expected the connection to be enabled again, got %v", err)
+	}
+
+	if enables != 2 {
+		t.Errorf("expected 2 attempts to enable the connection, got %d", enables)
+	}
+}
+
+func TestGetPPPStatus(t *testing.T) {
+	srv := httptest.NewServer(&apiHandler{respond: func(w http.ResponseWriter, _ *apiCall) {
+		_, _ = w.Write([]byte(`{"status":{"ppp":{
+			"ppp_backup":{"Enable":true,"ConnectionStatus":"Disconnected"},
+			"ppp_data":{"Enable":true,"ConnectionStatus":"Connected"},
+			"ppp_voip":{"Enable":false,"ConnectionStatus":"Disconnected"}
+		}}}`))
+	}})
+	defer srv.Close()
+
+	c, err := livebox.NewClient("password", livebox.WithAddress(srv.URL), livebox.WithoutKeepAlive())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 10 {
+		status, err := c.GetPPPStatus(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if status.ConnectionStatus != "Connected" {
+			t.Fatalf("expected the status of ppp_data, got %+v", status)
+		}
+	}
+}
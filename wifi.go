@@ -0,0 +1,113 @@
+package livebox
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Tomy2e/livebox-api-client/api/request"
+	"github.com/Tomy2e/livebox-api-client/api/response"
+	"github.com/Tomy2e/livebox-api-client/api/sah"
+)
+
+// GetWiFiStatus returns the global state of the Wi-Fi.
+func (c *Client) GetWiFiStatus(ctx context.Context) (*response.WiFiStatus, error) {
+	var out response.Status[response.WiFiStatus]
+	if err := c.Request(ctx, sah.NMCWifi.Get.Request(nil), &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Status, nil
+}
+
+// SetWiFiEnabled enables or disables the Wi-Fi on all bands. The guest
+// network is also affected.
+func (c *Client) SetWiFiEnabled(ctx context.Context, enable bool) error {
+	return c.requestBool(ctx, sah.NMCWifi.Set.Request(request.Parameters{
+		"Enable": enable,
+		"Status": enable,
+	}))
+}
+
+// GetWiFiAccessPoints returns the Wi-Fi access points, sorted by name.
+func (c *Client) GetWiFiAccessPoints(ctx context.Context) ([]response.WiFiAccessPoint, error) {
+	var out response.Status[struct {
+		WLANVAP map[string]response.WiFiAccessPoint `json:"wlanvap"`
+	}]
+	if err := c.Request(ctx, sah.NeMoIntfLan.GetMIBs.Request(request.Parameters{"mibs": "wlanvap"}), &out); err != nil {
+		return nil, err
+	}
+
+	aps := make([]response.WiFiAccessPoint, 0, len(out.Status.WLANVAP))
+	for name, ap := range out.Status.WLANVAP {
+		ap.Name = name
+		aps = append(aps, ap)
+	}
+
+	sort.Slice(aps, func(i, j int) bool { return aps[i].Name < aps[j].Name })
+
+	return aps, nil
+}
+
+// SetWiFiSSID sets the SSID of the given access points, or of all the access
+// points that do not belong to the guest network if none is given. Connected
+// devices are disconnected.
+func (c *Client) SetWiFiSSID(ctx context.Context, ssid string, accessPoints ...string) error {
+	return c.setAccessPoints(ctx, request.Parameters{"SSID": ssid}, accessPoints)
+}
+
+// SetWiFiPassword sets the passphrase of the given access points, or of all
+// the access points that do not belong to the guest network if none is given.
+// Connected devices are disconnected.
+func (c *Client) SetWiFiPassword(ctx context.Context, password string, accessPoints ...string) error {
+	return c.setAccessPoints(ctx, request.Parameters{
+		"Security": request.Parameters{"KeyPassPhrase": password},
+	}, accessPoints)
+}
+
+// setAccessPoints sets the same configuration on several access points.
+func (c *Client) setAccessPoints(ctx context.Context, cfg request.Parameters, accessPoints []string) error {
+	if len(accessPoints) == 0 {
+		aps, err := c.GetWiFiAccessPoints(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, ap := range aps {
+			if !ap.Guest() {
+				accessPoints = append(accessPoints, ap.Name)
+			}
+		}
+	}
+
+	vaps := make(request.Parameters, len(accessPoints))
+	for _, name := range accessPoints {
+		vaps[name] = cfg
+	}
+
+	return c.requestBool(ctx, sah.NeMoIntfLan.SetWLANConfig.Request(request.Parameters{
+		"mibs": request.Parameters{"wlanvap": vaps},
+	}))
+}
+
+// GetGuestWiFi returns whether the guest Wi-Fi network is enabled.
+func (c *Client) GetGuestWiFi(ctx context.Context) (bool, error) {
+	var out response.Status[struct {
+		Enable bool `json:"Enable"`
+	}]
+	if err := c.Request(ctx, sah.NMCGuest.Get.Request(nil), &out); err != nil {
+		return false, err
+	}
+
+	return out.Status.Enable, nil
+}
+
+// SetGuestWiFiEnabled enables or disables the guest Wi-Fi network.
+func (c *Client) SetGuestWiFiEnabled(ctx context.Context, enable bool) error {
+	return c.requestBool(ctx, sah.NMCGuest.Set.Request(request.Parameters{"Enable": enable}))
+}
+
+// StartWPS starts a WPS pairing session, devices can then connect by pushing
+// their WPS button for about two minutes.
+func (c *Client) StartWPS(ctx context.Context) error {
+	return c.requestBool(ctx, sah.NMCWifi.StartPairing.Request(request.Parameters{"clientPIN": ""}))
+}